@@ -0,0 +1,126 @@
+// Package agent runs one-shot prompts through the Claude CLI in
+// non-interactive (--print) mode, recording each call in traces, usage
+// stats, and debug logs.
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ryantking/agentctl/internal/stats"
+	"github.com/ryantking/agentctl/internal/trace"
+)
+
+// Binary is the agent CLI invoked for prompts.
+const Binary = "claude"
+
+// DefaultTimeout bounds a call when Request.Timeout is zero.
+const DefaultTimeout = 90 * time.Second
+
+// MaxInputBytes caps Request.Input; larger inputs are truncated, which is
+// still enough for the agent to summarize a change.
+const MaxInputBytes = 100 * 1024
+
+// ErrNotInstalled indicates the Claude CLI is not on PATH.
+var ErrNotInstalled = errors.New("claude CLI not found")
+
+// Request is a single prompt for the agent.
+type Request struct {
+	// Name briefly describes the call for traces and logs.
+	Name string
+	// Prompt is the instruction passed to the agent.
+	Prompt string
+	// Input is optional context (such as a diff) piped to the agent on stdin,
+	// which avoids command-line length limits for large inputs.
+	Input string
+	// Model overrides the agent's default model when non-empty.
+	Model string
+	// Dir is the working directory the agent runs in.
+	Dir string
+	// Timeout bounds the call; zero means DefaultTimeout.
+	Timeout time.Duration
+}
+
+// Installed reports whether the agent CLI is on PATH.
+func Installed() bool {
+	_, err := exec.LookPath(Binary)
+	return err == nil
+}
+
+// Run sends the request to the agent and returns its trimmed text response.
+func Run(ctx context.Context, req Request) (string, error) {
+	if !Installed() {
+		return "", ErrNotInstalled
+	}
+
+	timeout := req.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	args := []string{"--print", "--output-format", "text"}
+	if req.Model != "" {
+		args = append(args, "--model", req.Model)
+	}
+	args = append(args, req.Prompt)
+
+	cmd := exec.CommandContext(ctx, Binary, args...) //nolint:gosec // Arguments are built from trusted flags
+	cmd.Dir = req.Dir
+	cmd.Env = os.Environ()
+	if req.Input != "" {
+		cmd.Stdin = strings.NewReader(truncateInput(req.Input))
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	span := trace.StartSpan(trace.KindAgent, Binary+" "+req.Name)
+	if req.Model != "" {
+		span.SetAttr("model", req.Model)
+	}
+	slog.Debug("running agent", "agent", Binary, "call", req.Name, "model", req.Model, "dir", req.Dir, "input_bytes", len(req.Input))
+
+	start := time.Now()
+	out, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			err = fmt.Errorf("%s timed out after %s", Binary, timeout)
+		} else if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%s failed: %w: %s", Binary, err, msg)
+		}
+	}
+	span.End(err)
+	stats.RecordAgent(Binary, time.Since(start), err)
+	slog.Debug("agent finished", "agent", Binary, "call", req.Name, "duration", time.Since(start), "err", err)
+	if err != nil {
+		return "", err
+	}
+
+	response := strings.TrimSpace(string(out))
+	if response == "" {
+		return "", fmt.Errorf("empty output from Claude CLI")
+	}
+	return response, nil
+}
+
+// truncateInput cuts input to MaxInputBytes, backing up to a rune boundary
+// so a multi-byte character is never split, and notes the truncation.
+func truncateInput(input string) string {
+	if len(input) <= MaxInputBytes {
+		return input
+	}
+	n := MaxInputBytes
+	for n > 0 && !utf8.RuneStart(input[n]) {
+		n--
+	}
+	return input[:n] + "\n[input truncated]\n"
+}
@@ -0,0 +1,134 @@
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// fakeClaude puts a claude script on PATH that records its arguments,
+// working directory, and stdin in the returned directory, then runs body.
+func fakeClaude(t *testing.T, body string) string {
+	t.Helper()
+	bin := t.TempDir()
+	record := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"printf '%s\\n' \"$@\" > \"" + record + "/args\"\n" +
+		"pwd > \"" + record + "/dir\"\n" +
+		"cat > \"" + record + "/stdin\"\n" +
+		body + "\n"
+	if err := os.WriteFile(filepath.Join(bin, Binary), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return record
+}
+
+func readRecord(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestRun(t *testing.T) {
+	record := fakeClaude(t, "echo '  feat: add widgets  '")
+	workDir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Run(context.Background(), Request{
+		Name:   "commit message",
+		Prompt: "Write a commit message",
+		Input:  "diff --git a/x b/x",
+		Model:  "haiku",
+		Dir:    workDir,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got != "feat: add widgets" {
+		t.Errorf("Run() = %q, want the trimmed response", got)
+	}
+
+	wantArgs := "--print\n--output-format\ntext\n--model\nhaiku\nWrite a commit message\n"
+	if args := readRecord(t, record, "args"); args != wantArgs {
+		t.Errorf("args = %q, want %q", args, wantArgs)
+	}
+	if dir := strings.TrimSpace(readRecord(t, record, "dir")); dir != workDir {
+		t.Errorf("ran in %q, want %q", dir, workDir)
+	}
+	if stdin := readRecord(t, record, "stdin"); stdin != "diff --git a/x b/x" {
+		t.Errorf("stdin = %q", stdin)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		timeout time.Duration
+		wantErr string
+	}{
+		{"stderr", "echo 'rate limited' >&2; exit 1", 0, "claude failed: exit status 1: rate limited"},
+		{"empty output", "echo '   '", 0, "empty output"},
+		{"timeout", "exec sleep 5", 50 * time.Millisecond, "timed out after 50ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClaude(t, tt.body)
+			_, err := Run(context.Background(), Request{Name: "test", Prompt: "hi", Timeout: tt.timeout})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Run() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("not installed", func(t *testing.T) {
+		t.Setenv("PATH", t.TempDir())
+		if _, err := Run(context.Background(), Request{Prompt: "hi"}); err != ErrNotInstalled {
+			t.Errorf("Run() error = %v, want ErrNotInstalled", err)
+		}
+	})
+}
+
+func TestTruncateInput(t *testing.T) {
+	const marker = "\n[input truncated]\n"
+	tests := []struct {
+		name  string
+		input string
+		want  int // bytes of input kept
+	}{
+		{"under limit", "short diff", len("short diff")},
+		{"at limit", strings.Repeat("a", MaxInputBytes), MaxInputBytes},
+		{"over limit", strings.Repeat("a", MaxInputBytes+10), MaxInputBytes},
+		// "é" is two bytes, so the limit falls inside a character
+		{"splits two-byte rune", "a" + strings.Repeat("é", MaxInputBytes/2), MaxInputBytes - 1},
+		// "€" is three bytes; the limit falls after its first byte
+		{"splits three-byte rune", strings.Repeat("a", MaxInputBytes-1) + "€€", MaxInputBytes - 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateInput(tt.input)
+			if !utf8.ValidString(got) {
+				t.Fatalf("truncateInput() produced invalid UTF-8")
+			}
+			kept := strings.TrimSuffix(got, marker)
+			if len(kept) != tt.want {
+				t.Errorf("kept %d bytes, want %d", len(kept), tt.want)
+			}
+			if truncated := kept != got; truncated != (len(tt.input) > MaxInputBytes) {
+				t.Errorf("truncation marker present = %v for a %d byte input", truncated, len(tt.input))
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/agent"
+	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+const commitPrompt = `Write a commit message for the staged changes in the diff on stdin.
+
+Use the Conventional Commits format: a subject line "<type>(<optional scope>): <summary>"
+of at most 72 characters, where type is one of feat, fix, docs, style, refactor,
+perf, test, build, ci, chore, or revert. If the change needs explanation, add a
+blank line and a short body wrapped at 72 characters explaining what and why.
+
+Output only the commit message, with no surrounding quotes, code fences, or commentary.`
+
+// codeFence matches a message the agent wrapped in a markdown code block.
+var codeFence = regexp.MustCompile("(?s)^```[a-z]*\n(.*)\n```$")
+
+// NewCommitCmd creates the commit command.
+func NewCommitCmd() *cobra.Command {
+	var all, yes, dryRun, jsonMode bool
+	var model string
+
+	cmd := &cobra.Command{
+		Use:   "commit",
+		Short: "Commit staged changes with an agent-written message",
+		Long: `Ask the Claude CLI for a Conventional Commits message describing the staged
+changes, show it for confirmation, and create the commit.
+
+By default only staged changes are committed (--staged). With --all, changes
+to tracked files are staged first, like git commit --all.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			fail := func(err error) error {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			repoRoot, err := git.GetRepoRoot()
+			if err != nil {
+				return fail(err)
+			}
+
+			if all && !dryRun {
+				if err := git.StageTracked(repoRoot); err != nil {
+					return fail(fmt.Errorf("failed to stage changes: %w", err))
+				}
+			}
+
+			diff, err := git.StagedDiff(repoRoot)
+			if err != nil {
+				return fail(err)
+			}
+			if diff == "" {
+				return fail(errors.New("nothing staged to commit (stage changes or use --all)"))
+			}
+
+			if !jsonMode {
+				fmt.Println("Generating commit message with Claude CLI...")
+			}
+			message, err := agent.Run(context.Background(), agent.Request{
+				Name:   "commit message",
+				Prompt: commitPrompt,
+				Input:  diff,
+				Model:  config.ResolveModel(model, ""),
+				Dir:    repoRoot,
+			})
+			if err != nil {
+				return fail(err)
+			}
+			message = cleanCommitMessage(message)
+
+			if dryRun {
+				if jsonMode {
+					return output.SuccessJSON(map[string]interface{}{"message": message, "committed": false})
+				}
+				fmt.Printf("\n%s\n", message)
+				return nil
+			}
+
+			if !yes && !jsonMode {
+				fmt.Printf("\n%s\n\n", message)
+				ok, err := ui.Confirm("Commit with this message?")
+				if err != nil {
+					return fail(fmt.Errorf("%w (use --yes to commit without confirmation)", err))
+				}
+				if !ok {
+					fmt.Println("Commit aborted")
+					return nil
+				}
+			}
+
+			if err := git.Commit(repoRoot, message); err != nil {
+				return fail(err)
+			}
+			commit, _ := git.RunGit(repoRoot, "rev-parse", "--short", "HEAD")
+
+			if jsonMode {
+				return output.SuccessJSON(map[string]interface{}{"message": message, "commit": commit, "committed": true})
+			}
+			fmt.Printf("Committed %s: %s\n", commit, strings.SplitN(message, "\n", 2)[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("staged", true, "Commit only staged changes (default)")
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "Stage changes to tracked files before committing")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Commit without asking for confirmation")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Print the generated message without committing")
+	cmd.Flags().StringVarP(&model, "model", "m", "", "Model used to write the message (defaults to $AGENTCTL_MODEL, then the Claude CLI default)")
+	cmd.Flags().BoolVarP(&jsonMode, "json", "j", false, "Output in JSON format (implies --yes)")
+	cmd.MarkFlagsMutuallyExclusive("staged", "all")
+
+	return cmd
+}
+
+// cleanCommitMessage strips code fences and quotes the agent may add despite
+// instructions.
+func cleanCommitMessage(message string) string {
+	message = strings.TrimSpace(message)
+	if m := codeFence.FindStringSubmatch(message); m != nil {
+		message = strings.TrimSpace(m[1])
+	}
+	if len(message) >= 2 && message[0] == '"' && message[len(message)-1] == '"' {
+		message = message[1 : len(message)-1]
+	}
+	return message
+}
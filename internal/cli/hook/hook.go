@@ -20,6 +20,7 @@ func NewHookCmd() *cobra.Command {
 		NewHookNotifyInputCmd(),
 		NewHookNotifyStopCmd(),
 		NewHookNotifyErrorCmd(),
+		NewHookSimulateCmd(),
 	)
 
 	return cmd
@@ -2,7 +2,6 @@ package hook
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/ryantking/agentctl/internal/hook"
 	"github.com/spf13/cobra"
@@ -25,7 +24,6 @@ the conversation before Claude processes the user's message.`,
 			}
 
 			fmt.Println(context)
-			os.Exit(0)
 			return nil
 		},
 	}
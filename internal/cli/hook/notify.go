@@ -1,7 +1,7 @@
 package hook
 
 import (
-	"os"
+	"log/slog"
 
 	"github.com/ryantking/agentctl/internal/hook"
 	"github.com/spf13/cobra"
@@ -25,8 +25,10 @@ func NewHookNotifyInputCmd() *cobra.Command {
 				message = args[0]
 			}
 			
-			_ = hook.NotifyInput(message)
-			os.Exit(0)
+			// Hooks always exit 0 so a failure never blocks the agent
+			if err := hook.NotifyInput(message); err != nil {
+				slog.Warn("notify-input hook failed", "err", err)
+			}
 			return nil
 		},
 	}
@@ -45,8 +47,10 @@ func NewHookNotifyStopCmd() *cobra.Command {
 			if input != nil {
 				transcriptPath = hook.GetTranscriptPath(input)
 			}
-			_ = hook.NotifyStop(transcriptPath)
-			os.Exit(0)
+			// Hooks always exit 0 so a failure never blocks the agent
+			if err := hook.NotifyStop(transcriptPath); err != nil {
+				slog.Warn("notify-stop hook failed", "err", err)
+			}
 			return nil
 		},
 	}
@@ -72,8 +76,10 @@ func NewHookNotifyErrorCmd() *cobra.Command {
 				message = args[0]
 			}
 			
-			_ = hook.NotifyError(message)
-			os.Exit(0)
+			// Hooks always exit 0 so a failure never blocks the agent
+			if err := hook.NotifyError(message); err != nil {
+				slog.Warn("notify-error hook failed", "err", err)
+			}
 			return nil
 		},
 	}
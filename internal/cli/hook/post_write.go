@@ -1,7 +1,7 @@
 package hook
 
 import (
-	"os"
+	"log/slog"
 
 	"github.com/ryantking/agentctl/internal/hook"
 	"github.com/spf13/cobra"
@@ -16,8 +16,10 @@ func NewHookPostWriteCmd() *cobra.Command {
 		RunE: func(_ *cobra.Command, _ []string) error {
 			input, _ := hook.GetStdinData()
 			filePath := hook.GetFilePath(input)
-			_ = hook.PostWrite(filePath)
-			os.Exit(0)
+			// Hooks always exit 0 so a failure never blocks the agent
+			if err := hook.PostWrite(filePath); err != nil {
+				slog.Warn("post-write hook failed", "err", err)
+			}
 			return nil
 		},
 	}
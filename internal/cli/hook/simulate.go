@@ -0,0 +1,117 @@
+package hook
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/hook"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewHookSimulateCmd creates the hook simulate command.
+func NewHookSimulateCmd() *cobra.Command {
+	var payloadPath, toolName, filePath, message, transcriptPath string
+	var jsonMode bool
+
+	cmd := &cobra.Command{
+		Use:   "simulate [event]",
+		Short: "Dry-run a hook handler with a provided or synthesized payload",
+		Long: `Runs the agentctl handler for a Claude Code hook event locally and prints what
+it would do (commits, notifications, injected context) without doing it.
+
+The payload is read from --payload, or synthesized from --tool, --file,
+--message, and --transcript. If no event is given, hook_event_name from the
+payload is used.
+
+Events: ` + strings.Join(hook.Events, ", ") + `
+
+Examples:
+  agentctl hook simulate PostToolUse --tool Edit --file internal/cli/root.go
+  agentctl hook simulate Stop --payload stop.json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			input := &hook.HookInput{}
+			if payloadPath != "" {
+				data, err := os.ReadFile(payloadPath) //nolint:gosec // Payload path is provided by the user
+				if err != nil {
+					return fail(jsonMode, err)
+				}
+				input, err = hook.ParseHookInput(data)
+				if err != nil {
+					return fail(jsonMode, fmt.Errorf("failed to parse payload: %w", err))
+				}
+			}
+
+			// Flags override payload fields
+			if toolName != "" {
+				input.ToolName = toolName
+			}
+			if filePath != "" {
+				if input.ToolInput == nil {
+					input.ToolInput = make(map[string]interface{})
+				}
+				input.ToolInput["file_path"] = filePath
+			}
+			if message != "" {
+				input.Message = message
+			}
+			if transcriptPath != "" {
+				input.TranscriptPath = transcriptPath
+			}
+
+			event := input.HookEventName
+			if len(args) > 0 {
+				event = args[0]
+			}
+			if event == "" {
+				return fail(jsonMode, fmt.Errorf("event required (pass it as an argument or set hook_event_name in the payload)"))
+			}
+
+			actions, err := hook.Simulate(event, input)
+			if err != nil {
+				return fail(jsonMode, err)
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(map[string]interface{}{
+					"event":   event,
+					"actions": actions,
+				})
+			}
+
+			fmt.Printf("Simulating %s (dry run)\n", event)
+			for _, action := range actions {
+				handler := action.Handler
+				if handler == "" {
+					handler = "-"
+				}
+				fmt.Printf("  %-13s %-14s %s\n", action.Kind, handler, action.Summary)
+				if out, ok := action.Details["output"].(string); ok {
+					for _, line := range strings.Split(out, "\n") {
+						fmt.Printf("      %s\n", line)
+					}
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&payloadPath, "payload", "p", "", "Path to a hook JSON payload")
+	cmd.Flags().StringVar(&toolName, "tool", "", "Tool name for PostToolUse (Edit, Write)")
+	cmd.Flags().StringVar(&filePath, "file", "", "File path for PostToolUse (tool_input.file_path)")
+	cmd.Flags().StringVar(&message, "message", "", "Notification message")
+	cmd.Flags().StringVar(&transcriptPath, "transcript", "", "Transcript path for Stop")
+	cmd.Flags().BoolVarP(&jsonMode, "json", "j", false, "Output result as JSON")
+
+	return cmd
+}
+
+func fail(jsonMode bool, err error) error {
+	if jsonMode {
+		return output.ErrorJSON(err)
+	}
+	output.Error(err)
+	return err
+}
@@ -1,27 +1,57 @@
 package cli
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/ryantking/agentctl/internal/config"
 	"github.com/ryantking/agentctl/internal/git"
 	"github.com/ryantking/agentctl/internal/output"
 	"github.com/ryantking/agentctl/internal/setup"
+	"github.com/ryantking/agentctl/internal/templates"
 	"github.com/spf13/cobra"
 )
 
 // NewInitCmd creates the init command.
 func NewInitCmd() *cobra.Command {
-	var globalInstall, force, noIndex bool
+	var globalInstall, force, noIndex, listProfiles, backup bool
+	var model, profileName, templateSource string
 
 	cmd := &cobra.Command{
 		Use:   "init",
 		Short: "Initialize Claude Code configuration",
 		Long: `Initialize Claude Code configuration. Installs CLAUDE.md, agents, skills, and settings from the bundled templates directory.
-By default, skips existing files.`,
-		RunE: func(_ *cobra.Command, _ []string) error {
+By default, skips existing files.
+
+Use --profile to install a subset of the bundled templates (see --list-profiles).
+
+Use --templates (or $AGENTCTL_TEMPLATES) to point at a local directory or git
+URL (optionally suffixed with #<ref>) laid out like the bundled templates.
+Files found there replace the bundled ones; everything else is still
+installed from the bundled templates.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if templateSource == "" {
+				templateSource = os.Getenv(templates.EnvVar)
+			}
+			if templateSource != "" {
+				if err := templates.Override(templateSource); err != nil {
+					output.Error(err)
+					return err
+				}
+			}
+
+			if listProfiles {
+				return printProfiles()
+			}
+
+			profile, err := templates.GetProfile(profileName)
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+
 			var target string
-			var err error
 
 			if globalInstall {
 				home, err := os.UserHomeDir()
@@ -43,6 +73,9 @@ By default, skips existing files.`,
 				output.Error(err)
 				return err
 			}
+			manager.SetModel(config.ResolveModel(model, ""))
+			manager.SetProfile(profile)
+			manager.SetBackup(config.ResolveBackup(cmd.Flags().Changed("backup"), backup))
 
 			if err := manager.Install(force, noIndex || globalInstall); err != nil {
 				output.Error(err)
@@ -55,7 +88,41 @@ By default, skips existing files.`,
 
 	cmd.Flags().BoolVarP(&globalInstall, "global", "g", false, "Install to $HOME/.claude instead of current repository")
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Overwrite existing files")
+	cmd.Flags().BoolVar(&backup, "backup", false, "Save overwritten files to .claude/.agentctl-backups/<timestamp>/ (defaults to $AGENTCTL_BACKUP)")
 	cmd.Flags().BoolVar(&noIndex, "no-index", false, "Skip Claude CLI repository indexing")
+	cmd.Flags().StringVarP(&model, "model", "m", "", "Model used for repository indexing (defaults to $AGENTCTL_MODEL, then the Claude CLI default)")
+	cmd.Flags().StringVarP(&profileName, "profile", "p", "", "Template profile to install (go, node, python, minimal, full)")
+	cmd.Flags().BoolVar(&listProfiles, "list-profiles", false, "List available template profiles and exit")
+	cmd.Flags().StringVar(&templateSource, "templates", "", "Directory or git URL of templates overriding the bundled ones (defaults to $AGENTCTL_TEMPLATES)")
+	_ = cmd.RegisterFlagCompletionFunc("profile", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		profiles, _, err := templates.Profiles()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		names := make([]string, len(profiles))
+		for i, profile := range profiles {
+			names[i] = profile.Name + "\t" + profile.Description
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	})
 
 	return cmd
 }
+
+// printProfiles lists the template profiles available to init.
+func printProfiles() error {
+	profiles, defaultName, err := templates.Profiles()
+	if err != nil {
+		output.Error(err)
+		return err
+	}
+
+	for _, profile := range profiles {
+		name := profile.Name
+		if name == defaultName {
+			name += " (default)"
+		}
+		fmt.Printf("%-18s %s\n", name, profile.Description)
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package cli
+
+import (
+	"github.com/ryantking/agentctl/internal/cli/mcp"
+	"github.com/spf13/cobra"
+)
+
+// NewMCPCmd creates the mcp command group.
+func NewMCPCmd() *cobra.Command {
+	return mcp.NewMCPCmd()
+}
@@ -0,0 +1,91 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/mcp"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewMCPAddCmd creates the mcp add command.
+func NewMCPAddCmd() *cobra.Command {
+	var serverType, url, command string
+	var serverArgs, env []string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add an MCP server",
+		Long: `Adds an MCP server to .mcp.json and enables it in .claude/settings.json.
+With --global, adds it to ~/.claude.json instead.
+
+Examples:
+  agentctl mcp add context7 --type http --url https://mcp.context7.com/mcp
+  agentctl mcp add github --type stdio --command npx --args -y,@modelcontextprotocol/server-github --env GITHUB_TOKEN=...`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			server := mcp.Server{
+				Name:    args[0],
+				Type:    serverType,
+				URL:     url,
+				Command: command,
+				Args:    serverArgs,
+			}
+			if len(env) > 0 {
+				server.Env = make(map[string]string, len(env))
+				for _, kv := range env {
+					key, value, ok := strings.Cut(kv, "=")
+					if !ok || key == "" {
+						err := fmt.Errorf("invalid --env value %q: expected KEY=VALUE", kv)
+						if jsonMode {
+							return output.ErrorJSON(err)
+						}
+						output.Error(err)
+						return err
+					}
+					server.Env[key] = value
+				}
+			}
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if err := cfg.Add(server, force); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(map[string]interface{}{
+					"server": server,
+					"path":   cfg.Path(),
+				})
+			}
+
+			fmt.Printf("Added MCP server %s (%s) to %s\n", server.Name, server.Type, displayPath(cfg.Path()))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&serverType, "type", "t", mcp.TypeHTTP, "Server transport: http, sse, or stdio")
+	cmd.Flags().StringVar(&url, "url", "", "Server URL (http and sse)")
+	cmd.Flags().StringVar(&command, "command", "", "Command to launch the server (stdio)")
+	cmd.Flags().StringSliceVar(&serverArgs, "args", nil, "Arguments for --command (comma-separated or repeated)")
+	cmd.Flags().StringArrayVarP(&env, "env", "e", nil, "Environment variable for the server as KEY=VALUE (repeatable)")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Replace an existing server with the same name")
+
+	return cmd
+}
@@ -0,0 +1,113 @@
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/mcp"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewMCPListCmd creates the mcp list command.
+func NewMCPListCmd() *cobra.Command {
+	var showTools bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List configured MCP servers",
+		Long:  "Lists configured MCP servers. With --tools, also shows each server's tools from the catalog cached by 'agentctl mcp refresh'.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			servers, err := cfg.List()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			var catalogs map[string]mcp.Catalog
+			if showTools {
+				catalogs, err = cfg.Catalogs()
+				if err != nil {
+					if jsonMode {
+						return output.ErrorJSON(err)
+					}
+					output.Error(err)
+					return err
+				}
+			}
+
+			if jsonMode {
+				if !showTools {
+					return output.WriteJSON(servers)
+				}
+				data := make([]map[string]interface{}, len(servers))
+				for i, s := range servers {
+					data[i] = map[string]interface{}{
+						"name":    s.Name,
+						"type":    s.Type,
+						"url":     s.URL,
+						"command": s.Command,
+						"catalog": catalogs[s.Name],
+					}
+				}
+				return output.WriteJSON(data)
+			}
+
+			if len(servers) == 0 {
+				fmt.Printf("\n  No MCP servers configured in %s.\n\n  Add one with: agentctl mcp add <name> --type http --url <url>\n\n", displayPath(cfg.Path()))
+				return nil
+			}
+
+			for _, s := range servers {
+				target := s.URL
+				if s.Type == mcp.TypeStdio || target == "" {
+					target = strings.TrimSpace(s.Command + " " + strings.Join(s.Args, " "))
+				}
+				_, _ = fmt.Fprintf(os.Stdout, "  %-20s %-6s %s\n", s.Name, s.Type, target)
+				if showTools {
+					printCatalog(catalogs, s.Name)
+				}
+			}
+			fmt.Println()
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&showTools, "tools", "t", false, "Show cached tool catalogs (see 'agentctl mcp refresh')")
+
+	return cmd
+}
+
+// printCatalog prints the cached tools for a server.
+func printCatalog(catalogs map[string]mcp.Catalog, name string) {
+	catalog, ok := catalogs[name]
+	switch {
+	case !ok:
+		fmt.Println("      (not refreshed; run: agentctl mcp refresh)")
+	case catalog.Error != "":
+		fmt.Printf("      (refresh failed: %s)\n", catalog.Error)
+	default:
+		for _, tool := range catalog.Tools {
+			description := strings.SplitN(tool.Description, "\n", 2)[0]
+			if len(description) > 70 {
+				description = description[:67] + "..."
+			}
+			fmt.Printf("      %-30s %s\n", tool.Name, description)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Package mcp provides MCP server management CLI commands.
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/mcp"
+	"github.com/spf13/cobra"
+)
+
+// NewMCPCmd creates the mcp command group.
+func NewMCPCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Manage MCP server configuration",
+		Long:  "Commands for adding, removing, and listing MCP servers in .mcp.json (or ~/.claude.json with --global) without hand-editing JSON.",
+	}
+
+	cmd.PersistentFlags().BoolP("json", "j", false, "Output result as JSON")
+	cmd.PersistentFlags().BoolP("global", "g", false, "Use the user-level config in ~/.claude.json instead of the current repository")
+
+	cmd.AddCommand(
+		NewMCPAddCmd(),
+		NewMCPRemoveCmd(),
+		NewMCPListCmd(),
+		NewMCPRefreshCmd(),
+	)
+
+	return cmd
+}
+
+// loadConfig returns the project or global MCP config based on the --global flag.
+func loadConfig(cmd *cobra.Command) (*mcp.Config, error) {
+	global, _ := cmd.Flags().GetBool("global")
+	if global {
+		return mcp.NewGlobalConfig()
+	}
+	repoRoot, err := git.GetRepoRoot()
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewProjectConfig(repoRoot), nil
+}
+
+// displayPath returns the config path relative to the working directory when possible.
+func displayPath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+	if rel, err := filepath.Rel(wd, path); err == nil && !filepath.IsAbs(rel) && len(rel) < len(path) {
+		return rel
+	}
+	return path
+}
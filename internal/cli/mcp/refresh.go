@@ -0,0 +1,120 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/mcp"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewMCPRefreshCmd creates the mcp refresh command.
+func NewMCPRefreshCmd() *cobra.Command {
+	opts := mcp.DefaultRefreshOptions()
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "refresh [name...]",
+		Short: "Fetch and cache tool catalogs from configured MCP servers",
+		Long: `Connects to configured MCP servers (stdio and http), lists their tools and
+prompts, and caches the summaries for 'mcp list --tools'.
+
+Servers refreshed within --max-age are skipped unless --force is given, and at
+most a few servers are contacted at once. SSE servers and servers requiring
+OAuth are recorded with an error instead of a catalog.`,
+		ValidArgsFunction: completeServerNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			servers, err := cfg.List()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if len(args) > 0 {
+				servers, err = filterServers(servers, args)
+				if err != nil {
+					if jsonMode {
+						return output.ErrorJSON(err)
+					}
+					output.Error(err)
+					return err
+				}
+			}
+
+			if force {
+				opts.MaxAge = 0
+			}
+
+			catalogs, refreshed, err := cfg.Refresh(context.Background(), servers, opts)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(map[string]interface{}{
+					"refreshed": refreshed,
+					"catalogs":  catalogs,
+				})
+			}
+
+			fresh := make(map[string]bool, len(refreshed))
+			for _, name := range refreshed {
+				fresh[name] = true
+			}
+			for _, catalog := range catalogs {
+				state := "cached"
+				if fresh[catalog.Server] {
+					state = "refreshed"
+				}
+				if catalog.Error != "" {
+					fmt.Printf("  ✗ %-20s %s\n", catalog.Server, catalog.Error)
+					continue
+				}
+				fmt.Printf("  ✓ %-20s %d tool(s), %d prompt(s) (%s)\n", catalog.Server, len(catalog.Tools), len(catalog.Prompts), state)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Refresh every server regardless of cache age")
+	cmd.Flags().DurationVar(&opts.MaxAge, "max-age", opts.MaxAge, "Skip servers refreshed more recently than this")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", opts.Timeout, "Timeout per server")
+
+	return cmd
+}
+
+// filterServers returns the named servers, failing on unknown names.
+func filterServers(servers []mcp.Server, names []string) ([]mcp.Server, error) {
+	byName := make(map[string]mcp.Server, len(servers))
+	for _, s := range servers {
+		byName[s.Name] = s
+	}
+	filtered := make([]mcp.Server, 0, len(names))
+	for _, name := range names {
+		s, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", mcp.ErrServerNotFound, name)
+		}
+		filtered = append(filtered, s)
+	}
+	return filtered, nil
+}
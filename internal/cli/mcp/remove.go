@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewMCPRemoveCmd creates the mcp remove command.
+func NewMCPRemoveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove <name>",
+		Aliases:           []string{"rm"},
+		Short:             "Remove an MCP server",
+		Long:              "Removes an MCP server from .mcp.json and from enabledMcpjsonServers in .claude/settings.json. With --global, removes it from ~/.claude.json.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeServerNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			name := args[0]
+
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if err := cfg.Remove(name); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(map[string]interface{}{
+					"removed": name,
+					"path":    cfg.Path(),
+				})
+			}
+
+			fmt.Printf("Removed MCP server %s from %s\n", name, displayPath(cfg.Path()))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// completeServerNames provides completion for configured MCP server names.
+func completeServerNames(cmd *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	servers, err := cfg.List()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	names := make([]string, 0, len(servers))
+	for _, s := range servers {
+		names = append(names, s.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,11 @@
+package cli
+
+import (
+	"github.com/ryantking/agentctl/internal/cli/memory"
+	"github.com/spf13/cobra"
+)
+
+// NewMemoryCmd creates the memory command group.
+func NewMemoryCmd() *cobra.Command {
+	return memory.NewMemoryCmd()
+}
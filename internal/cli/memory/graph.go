@@ -0,0 +1,218 @@
+package memory
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/memory"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/setup"
+	"github.com/spf13/cobra"
+)
+
+// NewMemoryGraphCmd creates the memory graph command.
+func NewMemoryGraphCmd() *cobra.Command {
+	var format string
+	var budget int
+
+	cmd := &cobra.Command{
+		Use:   "graph [file...]",
+		Short: "Show the @import tree of memory files",
+		Long: `Walks @imports starting from CLAUDE.md and AGENTS.md (or the given files) and
+shows every imported file with its line count and estimated tokens, marking
+missing and circular imports. With --budget, warns when the combined memory
+exceeds that many tokens.
+
+Formats:
+  tree     Indented tree (default)
+  dot      Graphviz DOT
+  mermaid  Mermaid flowchart`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			repoRoot, err := git.GetRepoRoot()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			roots := args
+			if len(roots) == 0 {
+				for _, name := range setup.MemoryFiles {
+					path := filepath.Join(repoRoot, name)
+					if _, err := os.Stat(path); err == nil {
+						roots = append(roots, path)
+					}
+				}
+			}
+			if len(roots) == 0 {
+				err := fmt.Errorf("no memory files found in %s", repoRoot)
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+			for i, root := range roots {
+				if abs, err := filepath.Abs(root); err == nil {
+					roots[i] = abs
+				}
+			}
+
+			nodes := memory.BuildGraph(roots)
+			rel := func(path string) string {
+				if r, err := filepath.Rel(repoRoot, path); err == nil && !strings.HasPrefix(r, "..") {
+					return r
+				}
+				return path
+			}
+
+			total := memory.TotalTokens(nodes)
+			overBudget := budget > 0 && total > budget
+
+			if jsonMode {
+				return output.WriteJSON(map[string]interface{}{
+					"files":       nodes,
+					"tokens":      total,
+					"budget":      budget,
+					"over_budget": overBudget,
+				})
+			}
+
+			switch format {
+			case "tree":
+				for _, node := range nodes {
+					printTree(node, rel, "", true, true)
+				}
+				fmt.Printf("\nTotal: ~%d tokens\n", total)
+			case "dot":
+				printDOT(nodes, rel)
+			case "mermaid":
+				printMermaid(nodes, rel)
+			default:
+				err := fmt.Errorf("invalid format %q: must be tree, dot, or mermaid", format)
+				output.Error(err)
+				return err
+			}
+
+			if overBudget {
+				fmt.Fprintf(os.Stderr, "Warning: memory files use ~%d tokens, over the budget of %d\n", total, budget)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "tree", "Output format: tree, dot, or mermaid")
+	cmd.Flags().IntVar(&budget, "budget", 0, "Warn when memory files exceed this many estimated tokens")
+	_ = cmd.RegisterFlagCompletionFunc("format", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		return []string{"tree", "dot", "mermaid"}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+// printTree prints node and its imports as an indented tree.
+func printTree(node *memory.Node, rel func(string) string, prefix string, last, root bool) {
+	branch := ""
+	childPrefix := ""
+	if !root {
+		branch = "├── "
+		childPrefix = prefix + "│   "
+		if last {
+			branch = "└── "
+			childPrefix = prefix + "    "
+		}
+	}
+
+	fmt.Printf("%s%s%s %s\n", prefix, branch, rel(node.Path), nodeStatus(node))
+	for i, child := range node.Imports {
+		printTree(child, rel, childPrefix, i == len(node.Imports)-1, false)
+	}
+}
+
+func nodeStatus(node *memory.Node) string {
+	switch {
+	case node.Missing:
+		return "(missing)"
+	case node.Circular:
+		return "(circular)"
+	case node.TooDeep:
+		return fmt.Sprintf("(%d lines, ~%d tokens, imports beyond depth %d not followed)", node.Lines, node.Tokens, memory.MaxImportDepth)
+	default:
+		return fmt.Sprintf("(%d lines, ~%d tokens)", node.Lines, node.Tokens)
+	}
+}
+
+// edges returns each unique parent -> child import once, in walk order.
+func edges(nodes []*memory.Node, visit func(parent, child *memory.Node)) {
+	seen := make(map[string]bool)
+	var walk func(node *memory.Node)
+	walk = func(node *memory.Node) {
+		for _, child := range node.Imports {
+			key := node.Path + "\x00" + child.Path
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			visit(node, child)
+			walk(child)
+		}
+	}
+	for _, node := range nodes {
+		walk(node)
+	}
+}
+
+func printDOT(nodes []*memory.Node, rel func(string) string) {
+	fmt.Println("digraph memory {")
+	fmt.Println("  rankdir=LR;")
+	for _, node := range nodes {
+		fmt.Printf("  %q [label=%q];\n", rel(node.Path), rel(node.Path)+"\n"+nodeStatus(node))
+	}
+	edges(nodes, func(parent, child *memory.Node) {
+		if child.Circular {
+			// The target is already declared; only mark the back edge
+			fmt.Printf("  %q -> %q [style=dashed, color=red, label=\"circular\"];\n", rel(parent.Path), rel(child.Path))
+			return
+		}
+		attrs := fmt.Sprintf("label=%q", rel(child.Path)+"\n"+nodeStatus(child))
+		if child.Missing {
+			attrs += ", color=red"
+		}
+		fmt.Printf("  %q [%s];\n", rel(child.Path), attrs)
+		fmt.Printf("  %q -> %q;\n", rel(parent.Path), rel(child.Path))
+	})
+	fmt.Println("}")
+}
+
+func printMermaid(nodes []*memory.Node, rel func(string) string) {
+	ids := make(map[string]string)
+	id := func(node *memory.Node) string {
+		if existing, ok := ids[node.Path]; ok {
+			return existing
+		}
+		ids[node.Path] = fmt.Sprintf("n%d", len(ids))
+		return ids[node.Path]
+	}
+	label := func(node *memory.Node) string {
+		return strings.ReplaceAll(rel(node.Path)+" "+nodeStatus(node), `"`, "'")
+	}
+
+	fmt.Println("flowchart LR")
+	for _, node := range nodes {
+		fmt.Printf("  %s[\"%s\"]\n", id(node), label(node))
+	}
+	edges(nodes, func(parent, child *memory.Node) {
+		if child.Circular {
+			fmt.Printf("  %s -. circular .-> %s\n", id(parent), id(child))
+			return
+		}
+		fmt.Printf("  %s --> %s[\"%s\"]\n", id(parent), id(child), label(child))
+	})
+}
@@ -0,0 +1,24 @@
+// Package memory provides CLI commands for maintaining agent memory files.
+package memory
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewMemoryCmd creates the memory command group.
+func NewMemoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "memory",
+		Short: "Maintain agent memory files",
+		Long:  "Commands for maintaining the memory files (CLAUDE.md, AGENTS.md) that agents load at the start of a session.",
+	}
+
+	cmd.PersistentFlags().BoolP("json", "j", false, "Output result as JSON")
+
+	cmd.AddCommand(
+		NewMemoryUpdateCmd(),
+		NewMemoryGraphCmd(),
+	)
+
+	return cmd
+}
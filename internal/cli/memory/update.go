@@ -0,0 +1,192 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/setup"
+	"github.com/spf13/cobra"
+)
+
+// NewMemoryUpdateCmd creates the memory update command.
+func NewMemoryUpdateCmd() *cobra.Command {
+	var showDiff, backup bool
+	var staleDays int
+	var model string
+
+	cmd := &cobra.Command{
+		Use:     "update",
+		Aliases: []string{"index"},
+		Short:   "Regenerate the repository index in memory files",
+		Long: `Re-runs repository indexing with the Claude CLI and replaces the block between
+the REPOSITORY_INDEX markers in CLAUDE.md and AGENTS.md. Content outside the
+markers is left untouched.
+
+With --stale-days, indexing is skipped when every index was updated within
+that many days.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			repoRoot, err := git.GetRepoRoot()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			indexes, err := findIndexes(repoRoot)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if staleDays > 0 && allFresh(indexes, time.Duration(staleDays)*24*time.Hour) {
+				if jsonMode {
+					return output.SuccessJSON(map[string]interface{}{
+						"skipped": true,
+						"indexes": indexes,
+					})
+				}
+				fmt.Printf("Repository index updated within the last %d day(s); skipping\n", staleDays)
+				return nil
+			}
+
+			manager, err := setup.NewManager(repoRoot)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+			manager.SetModel(config.ResolveModel(model, ""))
+			manager.SetBackup(config.ResolveBackup(cmd.Flags().Changed("backup"), backup))
+
+			unlock, err := manager.Lock()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+			defer unlock()
+
+			if !jsonMode {
+				fmt.Println("Indexing repository with Claude CLI...")
+			}
+			content, err := manager.GenerateIndex()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			now := time.Now()
+			updated := make([]map[string]interface{}, 0, len(indexes))
+			for _, index := range indexes {
+				relPath, _ := filepath.Rel(repoRoot, index.Path)
+				changed := index.Content != content
+
+				var diff string
+				if showDiff && changed {
+					diff, err = git.DiffText(relPath, index.Content+"\n", content+"\n")
+					if err != nil {
+						if jsonMode {
+							return output.ErrorJSON(err)
+						}
+						output.Error(err)
+						return err
+					}
+				}
+
+				if err := manager.UpdateRepositoryIndex(index.Path, content, now); err != nil {
+					if jsonMode {
+						return output.ErrorJSON(err)
+					}
+					output.Error(err)
+					return err
+				}
+
+				entry := map[string]interface{}{
+					"path":    relPath,
+					"changed": changed,
+				}
+				if showDiff {
+					entry["diff"] = diff
+				}
+				updated = append(updated, entry)
+
+				if jsonMode {
+					continue
+				}
+				status := "updated"
+				if !changed {
+					status = "unchanged"
+				}
+				fmt.Printf("  • %s (%s)\n", relPath, status)
+				if diff != "" {
+					fmt.Print(diff)
+				}
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(map[string]interface{}{
+					"skipped": false,
+					"files":   updated,
+				})
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&showDiff, "diff", false, "Show a diff of the index changes")
+	cmd.Flags().BoolVar(&backup, "backup", false, "Save overwritten files to .claude/.agentctl-backups/<timestamp>/ (defaults to $AGENTCTL_BACKUP)")
+	cmd.Flags().IntVar(&staleDays, "stale-days", 0, "Skip if every index was updated within this many days")
+	cmd.Flags().StringVarP(&model, "model", "m", "", "Model used for indexing (defaults to $AGENTCTL_MODEL, then the Claude CLI default)")
+
+	return cmd
+}
+
+// findIndexes returns the repository index of each memory file that has one.
+func findIndexes(repoRoot string) ([]*setup.RepositoryIndex, error) {
+	var indexes []*setup.RepositoryIndex
+	for _, name := range setup.MemoryFiles {
+		index, err := setup.ReadRepositoryIndex(filepath.Join(repoRoot, name))
+		if os.IsNotExist(err) || errors.Is(err, setup.ErrIndexMarkersNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, index)
+	}
+	if len(indexes) == 0 {
+		return nil, fmt.Errorf("no memory file contains REPOSITORY_INDEX markers (run agentctl init first)")
+	}
+	return indexes, nil
+}
+
+// allFresh reports whether every index was updated within maxAge.
+func allFresh(indexes []*setup.RepositoryIndex, maxAge time.Duration) bool {
+	for _, index := range indexes {
+		if index.Updated.IsZero() || time.Since(index.Updated) > maxAge {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/setup"
+	"github.com/spf13/cobra"
+)
+
+// NewRestoreCmd creates the restore command.
+func NewRestoreCmd() *cobra.Command {
+	var globalInstall, dryRun bool
+	var from string
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Roll back files saved by a --backup run",
+		Long: `Restore the files that an init, upgrade, or memory update run with --backup
+overwrote. Each run saves the previous versions under
+.claude/.agentctl-backups/<timestamp>/. Files the run created, rather than
+overwrote, are left in place.
+
+Without --from, lists the available backups.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			target, err := restoreTarget(globalInstall)
+			if err != nil {
+				if globalInstall {
+					output.Errorf("failed to get home directory: %v", err)
+				} else {
+					output.Errorf("%v\n\nRun from inside a git repository or use --global", err)
+				}
+				return err
+			}
+
+			manager, err := setup.NewManager(target)
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+
+			if from == "" {
+				backups, err := manager.Backups()
+				if err != nil {
+					output.Error(err)
+					return err
+				}
+				if len(backups) == 0 {
+					fmt.Println("No backups found")
+					return nil
+				}
+				fmt.Println("Backups (restore with --from <timestamp>):")
+				for _, id := range backups {
+					fmt.Printf("  • %s\n", id)
+				}
+				return nil
+			}
+
+			restored, err := manager.Restore(from, dryRun)
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+
+			verb := "restored"
+			if dryRun {
+				verb = "would restore"
+			}
+			for _, relPath := range restored {
+				fmt.Printf("  • %s (%s)\n", relPath, verb)
+			}
+			if dryRun {
+				fmt.Println("\nDry run: nothing was changed")
+				return nil
+			}
+			fmt.Printf("\n✓ Restored %d file(s) from %s\n", len(restored), from)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Timestamp of the backup to restore")
+	cmd.Flags().BoolVarP(&globalInstall, "global", "g", false, "Restore $HOME/.claude instead of current repository")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Show what would be restored without changing anything")
+	_ = cmd.RegisterFlagCompletionFunc("from", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		target, err := restoreTarget(globalInstall)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		manager, err := setup.NewManager(target)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		backups, _ := manager.Backups()
+		return backups, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+// restoreTarget returns the directory restore works on: $HOME/.claude with
+// --global, otherwise the repository root.
+func restoreTarget(globalInstall bool) (string, error) {
+	if globalInstall {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, ".claude"), nil
+	}
+	return git.GetRepoRoot()
+}
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/agent"
+	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/memory"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/review"
+	"github.com/ryantking/agentctl/internal/setup"
+	"github.com/ryantking/agentctl/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+const reviewPrompt = `Review the diff on stdin as a senior engineer. Report bugs, security problems,
+missing error handling, and clear violations of the repository guidelines
+(if any are included before the diff). Ignore pure style preferences.
+
+Respond with only a JSON object in exactly this shape, with line numbers
+referring to the new version of each file:
+
+%s
+
+Use an empty issues array if there is nothing to report.`
+
+// NewReviewCmd creates the review command.
+func NewReviewCmd() *cobra.Command {
+	var base, model, failOn string
+	var withRules, jsonMode bool
+
+	cmd := &cobra.Command{
+		Use:   "review",
+		Short: "Review the current branch's changes with the Claude CLI",
+		Long: `Sends the changes since the current branch diverged from the base branch
+(committed and uncommitted) to the Claude CLI and prints a structured review:
+issues keyed by file:line with a severity and suggested fix.
+
+With --rules, the repository's memory files (CLAUDE.md, AGENTS.md, and their
+@imports) are included so the review checks the project's own guidelines.
+
+Use --json to consume the review in CI, and --fail-on to exit non-zero when
+issues at or above a severity are found.`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			fail := func(err error) error {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if failOn != "" && !review.ValidSeverity(failOn) {
+				return fail(fmt.Errorf("invalid --fail-on %q: expected error, warning, or info", failOn))
+			}
+
+			repoRoot, err := git.GetRepoRoot()
+			if err != nil {
+				return fail(err)
+			}
+
+			if base == "" {
+				manager, err := workspace.NewManagerAt(repoRoot)
+				if err != nil {
+					return fail(err)
+				}
+				base = manager.DefaultBaseBranch()
+			}
+
+			diff, err := git.DiffFromMergeBase(repoRoot, base)
+			if err != nil {
+				return fail(err)
+			}
+			if diff == "" {
+				return fail(fmt.Errorf("no changes against %s to review", base))
+			}
+
+			var input strings.Builder
+			if withRules {
+				input.WriteString(repositoryGuidelines(repoRoot))
+			}
+			input.WriteString("Diff:\n\n")
+			input.WriteString(diff)
+
+			if !jsonMode {
+				fmt.Fprintf(os.Stderr, "Reviewing changes against %s with Claude CLI...\n", base)
+			}
+			response, err := agent.Run(context.Background(), agent.Request{
+				Name:   "review",
+				Prompt: fmt.Sprintf(reviewPrompt, review.Schema),
+				Input:  input.String(),
+				Model:  config.ResolveModel(model, ""),
+				Dir:    repoRoot,
+			})
+			if err != nil {
+				return fail(err)
+			}
+
+			result, err := review.Parse(response)
+			if err != nil {
+				return fail(err)
+			}
+
+			if jsonMode {
+				if err := output.WriteJSON(result); err != nil {
+					return err
+				}
+			} else {
+				printReview(result)
+			}
+
+			if failOn != "" {
+				if n := result.Count(failOn); n > 0 {
+					err := fmt.Errorf("review found %d issue(s) at or above %s", n, failOn)
+					if !jsonMode {
+						output.Error(err)
+					}
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&base, "base", "b", "", "Branch to compare against (defaults to the main worktree's branch)")
+	cmd.Flags().BoolVar(&withRules, "rules", false, "Include CLAUDE.md, AGENTS.md, and their imports as review guidelines")
+	cmd.Flags().StringVar(&failOn, "fail-on", "", "Exit non-zero if any issue is at or above this severity (error, warning, info)")
+	cmd.Flags().StringVarP(&model, "model", "m", "", "Model used for the review (defaults to $AGENTCTL_MODEL, then the Claude CLI default)")
+	cmd.Flags().BoolVarP(&jsonMode, "json", "j", false, "Output in JSON format")
+
+	return cmd
+}
+
+// repositoryGuidelines returns the repository's memory files, with imports,
+// formatted as prompt input.
+func repositoryGuidelines(repoRoot string) string {
+	var roots []string
+	for _, name := range setup.MemoryFiles {
+		roots = append(roots, filepath.Join(repoRoot, name))
+	}
+
+	var b strings.Builder
+	for _, path := range memory.Files(memory.BuildGraph(roots)) {
+		data, err := os.ReadFile(path) //nolint:gosec // Paths come from the repository's memory files
+		if err != nil {
+			continue
+		}
+		name := path
+		if rel, err := filepath.Rel(repoRoot, path); err == nil && !strings.HasPrefix(rel, "..") {
+			name = rel
+		}
+		fmt.Fprintf(&b, "Repository guidelines from %s:\n\n%s\n\n", name, data)
+	}
+	return b.String()
+}
+
+// printReview prints the review grouped by file.
+func printReview(r *review.Review) {
+	if r.Summary != "" {
+		fmt.Printf("%s\n\n", r.Summary)
+	}
+	if len(r.Issues) == 0 {
+		fmt.Println("No issues found.")
+		return
+	}
+
+	file := ""
+	for _, issue := range r.Issues {
+		if issue.File != file {
+			file = issue.File
+			fmt.Println(file)
+		}
+		fmt.Printf("  %-7s %s  %s\n", issue.Severity, issue.Location(), issue.Message)
+		if issue.Suggestion != "" {
+			fmt.Printf("          → %s\n", issue.Suggestion)
+		}
+	}
+	fmt.Printf("\n%d issue(s): %d error(s), %d warning(s)\n",
+		len(r.Issues), r.Count(review.SeverityError), r.Count(review.SeverityWarning)-r.Count(review.SeverityError))
+}
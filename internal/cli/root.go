@@ -1,12 +1,40 @@
 package cli
 
 import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/fsutil"
+	"github.com/ryantking/agentctl/internal/logging"
+	"github.com/ryantking/agentctl/internal/stats"
+	"github.com/ryantking/agentctl/internal/trace"
 	"github.com/spf13/cobra"
 )
 
+// closeLog closes the log file opened for this run, if any.
+var closeLog = func() error { return nil }
+
 // Execute runs the CLI application.
 func Execute() error {
-	return NewRootCmd().Execute()
+	defer func() { _ = closeLog() }()
+
+	start := time.Now()
+	cmd, err := NewRootCmd().ExecuteC()
+	stats.RecordCommand(cmd.CommandPath(), time.Since(start), err)
+	if statsErr := stats.Flush(); statsErr != nil {
+		slog.Warn("failed to write usage stats", "err", statsErr)
+	}
+
+	path, traceErr := trace.Finish(err)
+	if traceErr != nil {
+		slog.Warn("failed to write trace", "err", traceErr)
+	} else if path != "" {
+		fmt.Fprintf(os.Stderr, "Trace written to %s\n", path)
+	}
+
+	return err
 }
 
 // NewRootCmd creates the root command.
@@ -15,14 +43,65 @@ func NewRootCmd() *cobra.Command {
 		Use:   "agentctl",
 		Short: "A CLI tool for managing Claude Code configurations, hooks, and isolated workspaces using git worktrees",
 		Long:  "A CLI tool for managing Claude Code configurations, hooks, and isolated workspaces using git worktrees.",
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			opts := logging.Options{File: os.Getenv(logging.FileEnvVar)}
+			opts.Verbose, _ = cmd.Flags().GetBool("verbose")
+			opts.Debug, _ = cmd.Flags().GetBool("debug")
+			opts.Quiet, _ = cmd.Flags().GetBool("quiet")
+			if file, _ := cmd.Flags().GetString("log-file"); file != "" {
+				opts.File = file
+			}
+			closeFn, err := logging.Setup(opts)
+			if err != nil {
+				return err
+			}
+			closeLog = closeFn
+
+			fileMode := os.Getenv(fsutil.FileModeEnvVar)
+			if flag, _ := cmd.Flags().GetString("file-mode"); flag != "" {
+				fileMode = flag
+			}
+			if fileMode != "" {
+				mode, err := fsutil.ParseFileMode(fileMode)
+				if err != nil {
+					return err
+				}
+				fsutil.SetFileMode(mode)
+			}
+			slog.Debug("running command", "command", cmd.CommandPath(), "args", os.Args[1:])
+
+			enabled, _ := cmd.Flags().GetBool("trace")
+			if enabled || os.Getenv(trace.EnvVar) != "" {
+				trace.Begin(cmd.CommandPath())
+			}
+			return nil
+		},
 	}
 
+	cmd.PersistentFlags().Bool("trace", false, "Record timing spans for this run (view with agentctl trace show)")
+	cmd.PersistentFlags().BoolP("verbose", "v", false, "Log progress details to stderr")
+	cmd.PersistentFlags().Bool("debug", false, "Log debug details (git commands, agent calls) to stderr")
+	cmd.PersistentFlags().BoolP("quiet", "q", false, "Only log errors to stderr")
+	cmd.PersistentFlags().String("log-file", "", "Append JSON debug logs to this file (defaults to $AGENTCTL_LOG_FILE)")
+	cmd.PersistentFlags().String("file-mode", "", "Octal mode for files agentctl creates, e.g. 0600 (defaults to $AGENTCTL_FILE_MODE, then 0644)")
+
 	cmd.AddCommand(
 		NewVersionCmd(),
+		NewSelfUpdateCmd(),
 		NewStatusCmd(),
 		NewWorkspaceCmd(),
 		NewHookCmd(),
 		NewInitCmd(),
+		NewCommitCmd(),
+		NewReviewCmd(),
+		NewUninstallCmd(),
+		NewUpgradeCmd(),
+		NewRestoreCmd(),
+		NewMCPCmd(),
+		NewSettingsCmd(),
+		NewTraceCmd(),
+		NewStatsCmd(),
+		NewMemoryCmd(),
 	)
 
 	return cmd
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/release"
+	"github.com/spf13/cobra"
+)
+
+// NewSelfUpdateCmd creates the self-update command.
+func NewSelfUpdateCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "self-update",
+		Short: "Update agentctl to the latest release",
+		Long: `Download the latest agentctl release for this platform from GitHub, verify it
+against the release's checksums.txt, and replace the running binary.
+
+Development builds are not updated unless --force is given.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*releaseCheckTimeout)
+			defer cancel()
+
+			latest, err := release.Latest(ctx)
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+
+			if !force && !release.IsNewer(versionInfo.version, latest.Tag) {
+				if versionInfo.version == "dev" {
+					fmt.Printf("This is a development build; use --force to install %s\n", latest.Version())
+				} else {
+					fmt.Printf("agentctl %s is already the latest version\n", versionInfo.version)
+				}
+				return nil
+			}
+
+			exePath, err := os.Executable()
+			if err == nil {
+				exePath, err = filepath.EvalSymlinks(exePath)
+			}
+			if err != nil {
+				err = fmt.Errorf("failed to locate agentctl binary: %w", err)
+				output.Error(err)
+				return err
+			}
+
+			fmt.Printf("Updating agentctl %s -> %s\n", versionInfo.version, latest.Version())
+			if err := release.Install(ctx, latest, exePath); err != nil {
+				output.Error(err)
+				return err
+			}
+			fmt.Printf("  → Installed %s to %s\n", latest.Version(), exePath)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Reinstall even if already up to date")
+
+	return cmd
+}
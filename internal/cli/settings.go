@@ -0,0 +1,11 @@
+package cli
+
+import (
+	"github.com/ryantking/agentctl/internal/cli/settings"
+	"github.com/spf13/cobra"
+)
+
+// NewSettingsCmd creates the settings command group.
+func NewSettingsCmd() *cobra.Command {
+	return settings.NewSettingsCmd()
+}
@@ -0,0 +1,62 @@
+package settings
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewSettingsEffectiveCmd creates the settings effective command.
+func NewSettingsEffectiveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "effective",
+		Short: "Print the merged settings Claude Code will use",
+		Long: `Merges the user, project, and local settings layers in precedence order
+(local wins over project, project wins over user) and prints the result.
+Outside a git repository only the user layer is used.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			repoRoot, _ := git.GetRepoRoot()
+			layers, err := config.SettingsLayers(repoRoot)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			effective, err := config.Effective(layers)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(map[string]interface{}{
+					"layers":   layers,
+					"settings": effective,
+				})
+			}
+
+			for _, layer := range layers {
+				state := "missing"
+				if _, err := os.Stat(layer.Path); err == nil {
+					state = "loaded"
+				}
+				fmt.Fprintf(os.Stderr, "# %-8s %s (%s)\n", layer.Name, layer.Path, state)
+			}
+			return output.WriteJSON(effective)
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,140 @@
+package settings
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/spf13/cobra"
+)
+
+// NewSettingsSetCmd creates the settings set command.
+func NewSettingsSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a settings value",
+		Long: `Sets a dotted key (e.g. env.BASH_DEFAULT_TIMEOUT_MS) in the project settings,
+or in the local or user layer with --local or --global.
+Values are parsed as JSON when possible, so numbers, booleans, arrays, and
+objects keep their types; anything else is stored as a string.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			key, value := args[0], config.ParseValue(args[1])
+
+			layer, err := selectLayer(cmd)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			settings, err := config.LoadFile(layer.Path)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if err := config.SetPath(settings, key, value); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if err := config.SaveFile(layer.Path, settings); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(map[string]interface{}{
+					"layer": layer.Name,
+					"path":  layer.Path,
+					"key":   key,
+					"value": value,
+				})
+			}
+
+			fmt.Printf("Set %s in %s settings (%s)\n", key, layer.Name, layer.Path)
+			return nil
+		},
+	}
+
+	addLayerFlags(cmd)
+
+	return cmd
+}
+
+// NewSettingsUnsetCmd creates the settings unset command.
+func NewSettingsUnsetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a settings value",
+		Long:  "Removes a dotted key from the project settings, or from the local or user layer with --local or --global.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+			key := args[0]
+
+			layer, err := selectLayer(cmd)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			settings, err := config.LoadFile(layer.Path)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if !config.UnsetPath(settings, key) {
+				err := fmt.Errorf("%s is not set in %s settings", key, layer.Name)
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if err := config.SaveFile(layer.Path, settings); err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(map[string]interface{}{
+					"layer": layer.Name,
+					"path":  layer.Path,
+					"key":   key,
+				})
+			}
+
+			fmt.Printf("Unset %s in %s settings (%s)\n", key, layer.Name, layer.Path)
+			return nil
+		},
+	}
+
+	addLayerFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,74 @@
+// Package settings provides Claude Code settings management CLI commands.
+package settings
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// NewSettingsCmd creates the settings command group.
+func NewSettingsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "settings",
+		Short: "Manage layered Claude Code settings",
+		Long: `Commands for reading and writing Claude Code settings across its layers:
+user (~/.claude/settings.json), project (.claude/settings.json), and
+local (.claude/settings.local.json, gitignored and machine-specific).`,
+	}
+
+	cmd.PersistentFlags().BoolP("json", "j", false, "Output result as JSON")
+
+	cmd.AddCommand(
+		NewSettingsSetCmd(),
+		NewSettingsUnsetCmd(),
+		NewSettingsEffectiveCmd(),
+	)
+
+	return cmd
+}
+
+// addLayerFlags registers the flags used to pick a settings layer.
+func addLayerFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("local", false, "Target .claude/settings.local.json")
+	cmd.Flags().BoolP("global", "g", false, "Target ~/.claude/settings.json")
+}
+
+// selectLayer returns the layer chosen by --local/--global (project by default).
+func selectLayer(cmd *cobra.Command) (config.Layer, error) {
+	local, _ := cmd.Flags().GetBool("local")
+	global, _ := cmd.Flags().GetBool("global")
+	if local && global {
+		return config.Layer{}, fmt.Errorf("--local and --global are mutually exclusive")
+	}
+
+	name := config.LayerProject
+	switch {
+	case local:
+		name = config.LayerLocal
+	case global:
+		name = config.LayerUser
+	}
+
+	var repoRoot string
+	if !global {
+		var err error
+		repoRoot, err = git.GetRepoRoot()
+		if err != nil {
+			return config.Layer{}, fmt.Errorf("%w (use --global for user settings)", err)
+		}
+	}
+
+	layers, err := config.SettingsLayers(repoRoot)
+	if err != nil {
+		return config.Layer{}, err
+	}
+	for _, layer := range layers {
+		if layer.Name == name {
+			return layer, nil
+		}
+	}
+	return config.Layer{}, fmt.Errorf("unknown settings layer: %s", name)
+}
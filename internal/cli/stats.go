@@ -0,0 +1,11 @@
+package cli
+
+import (
+	"github.com/ryantking/agentctl/internal/cli/stats"
+	"github.com/spf13/cobra"
+)
+
+// NewStatsCmd creates the stats command group.
+func NewStatsCmd() *cobra.Command {
+	return stats.NewStatsCmd()
+}
@@ -0,0 +1,107 @@
+// Package stats provides CLI commands for local usage statistics.
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+// NewStatsCmd creates the stats command group.
+func NewStatsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show local usage statistics",
+		Long: `Show how often each agentctl command runs and how long agent calls take.
+
+Statistics are opt-in and never leave this machine. Enable them with
+agentctl stats enable; they are stored in the user config directory.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			s, err := stats.Load()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.WriteJSON(s)
+			}
+
+			if !s.Enabled && len(s.Commands) == 0 {
+				fmt.Println("Usage statistics are disabled. Run 'agentctl stats enable' to start recording.")
+				return nil
+			}
+
+			state := "enabled"
+			if !s.Enabled {
+				state = "disabled"
+			}
+			fmt.Printf("Usage statistics (%s)", state)
+			if !s.Since.IsZero() {
+				fmt.Printf(" since %s", s.Since.Local().Format("2006-01-02"))
+			}
+			fmt.Println()
+
+			printUsage("Commands", s.Commands)
+			printUsage("Agent calls", s.Agents)
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().BoolP("json", "j", false, "Output result as JSON")
+
+	cmd.AddCommand(
+		NewStatsEnableCmd(),
+		NewStatsDisableCmd(),
+		NewStatsResetCmd(),
+	)
+
+	return cmd
+}
+
+// printUsage prints a table of usage entries, most frequent first.
+func printUsage(title string, usage map[string]*stats.Usage) {
+	if len(usage) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(usage))
+	for name := range usage {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if usage[names[i]].Count != usage[names[j]].Count {
+			return usage[names[i]].Count > usage[names[j]].Count
+		}
+		return names[i] < names[j]
+	})
+
+	fmt.Printf("\n%s:\n", title)
+	fmt.Printf("  %-36s %6s %6s %9s %9s\n", "NAME", "RUNS", "FAILED", "AVG", "MAX")
+	for _, name := range names {
+		u := usage[name]
+		fmt.Printf("  %-36s %6d %6d %9s %9s\n", name, u.Count, u.Failures, formatDuration(u.Average()), formatDuration(u.Max))
+	}
+}
+
+// formatDuration rounds a duration for table display.
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(100 * time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(time.Millisecond).String()
+	default:
+		return d.Round(time.Microsecond).String()
+	}
+}
@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+// NewStatsEnableCmd creates the stats enable command.
+func NewStatsEnableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "enable",
+		Short: "Start recording usage statistics",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runStatsChange(cmd, func() error { return stats.SetEnabled(true) }, "Usage statistics enabled")
+		},
+	}
+}
+
+// NewStatsDisableCmd creates the stats disable command.
+func NewStatsDisableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable",
+		Short: "Stop recording usage statistics (collected data is kept)",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runStatsChange(cmd, func() error { return stats.SetEnabled(false) }, "Usage statistics disabled")
+		},
+	}
+}
+
+// NewStatsResetCmd creates the stats reset command.
+func NewStatsResetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reset",
+		Short: "Clear collected usage statistics",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runStatsChange(cmd, stats.Reset, "Usage statistics cleared")
+		},
+	}
+}
+
+func runStatsChange(cmd *cobra.Command, change func() error, message string) error {
+	jsonMode, _ := cmd.Flags().GetBool("json")
+
+	if err := change(); err != nil {
+		if jsonMode {
+			return output.ErrorJSON(err)
+		}
+		output.Error(err)
+		return err
+	}
+
+	if jsonMode {
+		return output.SuccessJSON(map[string]string{"message": message})
+	}
+	fmt.Println(message)
+	return nil
+}
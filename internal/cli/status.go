@@ -1,71 +1,279 @@
 package cli
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/setup"
+	"github.com/ryantking/agentctl/internal/templates"
+	"github.com/ryantking/agentctl/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
+// knownAgents lists the agent CLIs status probes, by display name and binary.
+var knownAgents = []struct {
+	name   string
+	binary string
+}{
+	{"claude", "claude"},
+	{"codex", "codex"},
+	{"cursor-agent", "cursor-agent"},
+	{"aider", "aider"},
+	{"gemini", "gemini"},
+}
+
+// versionProbeTimeout bounds each agent's --version call, since some CLIs
+// start slowly or prompt for login.
+const versionProbeTimeout = 5 * time.Second
+
+// AgentInfo describes an agent CLI found (or not) on PATH.
+type AgentInfo struct {
+	Name      string `json:"name"`
+	Installed bool   `json:"installed"`
+	Version   string `json:"version,omitempty"`
+	Path      string `json:"path,omitempty"`
+}
+
+// DefaultInfo is a configured default and where it came from.
+type DefaultInfo struct {
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// RepositoryInfo describes the current repository's agentctl setup.
+type RepositoryInfo struct {
+	Root       string   `json:"root"`
+	Branch     string   `json:"branch,omitempty"`
+	Workspaces int      `json:"workspaces"`
+	ClaudeMD   bool     `json:"claude_md"`
+	Installed  bool     `json:"installed"`
+	Profile    string   `json:"profile,omitempty"`
+	Problems   []string `json:"problems,omitempty"`
+}
+
 // StatusInfo represents system status information.
 type StatusInfo struct {
-	Claude struct {
-		Installed bool   `json:"installed"`
-		Version   string `json:"version,omitempty"`
-		Path      string `json:"path,omitempty"`
-	} `json:"claude"`
+	Agents     []AgentInfo            `json:"agents"`
+	Defaults   map[string]DefaultInfo `json:"defaults"`
+	Repository *RepositoryInfo        `json:"repository,omitempty"`
 }
 
 // NewStatusCmd creates the status command.
 func NewStatusCmd() *cobra.Command {
+	var jsonMode bool
+
 	cmd := &cobra.Command{
 		Use:   "status",
-		Short: "Show the status of Claude Code",
+		Short: "Show installed agent CLIs and agentctl configuration",
+		Long: `Show which agent CLIs (claude, codex, cursor-agent, aider, gemini) are on
+PATH and their versions, the defaults agentctl will use, and, inside a git
+repository, the health of its workspaces and Claude configuration.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
-			info := getClaudeInfo()
+			info := getStatusInfo()
+			if jsonMode {
+				return output.WriteJSON(info)
+			}
 			printStatus(info)
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVarP(&jsonMode, "json", "j", false, "Output in JSON format")
+
 	return cmd
 }
 
-func getClaudeInfo() StatusInfo {
-	var info StatusInfo
+func getStatusInfo() StatusInfo {
+	info := StatusInfo{
+		Agents:   probeAgents(),
+		Defaults: make(map[string]DefaultInfo),
+	}
 
-	claudePath, err := exec.LookPath("claude")
+	repoRoot, err := git.GetRepoRoot()
 	if err != nil {
-		return info
+		repoRoot = ""
 	}
 
-	info.Claude.Installed = true
-	info.Claude.Path = claudePath
+	info.Defaults["model"] = resolveModelDefault(repoRoot)
+	if source := os.Getenv(templates.EnvVar); source != "" {
+		info.Defaults["templates"] = DefaultInfo{Value: source, Source: templates.EnvVar}
+	} else {
+		info.Defaults["templates"] = DefaultInfo{Value: "bundled", Source: "default"}
+	}
 
-	// Try to get version
-	cmd := exec.Command("claude", "--version")
-	output, err := cmd.Output()
+	if repoRoot != "" {
+		info.Repository = getRepositoryInfo(repoRoot)
+	}
+	return info
+}
+
+// probeAgents looks up each known agent and asks it for its version in parallel.
+func probeAgents() []AgentInfo {
+	agents := make([]AgentInfo, len(knownAgents))
+
+	var wg sync.WaitGroup
+	for i, agent := range knownAgents {
+		agents[i].Name = agent.name
+		path, err := exec.LookPath(agent.binary)
+		if err != nil {
+			continue
+		}
+		agents[i].Installed = true
+		agents[i].Path = path
+
+		wg.Add(1)
+		go func(info *AgentInfo) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), versionProbeTimeout)
+			defer cancel()
+			out, err := exec.CommandContext(ctx, info.Path, "--version").Output() //nolint:gosec // Path comes from LookPath of a fixed binary name
+			if err == nil {
+				info.Version = firstLine(string(out))
+			}
+		}(&agents[i])
+	}
+	wg.Wait()
+
+	return agents
+}
+
+// resolveModelDefault reports the model agentctl passes to the Claude CLI,
+// falling back to the model set in Claude settings.
+func resolveModelDefault(repoRoot string) DefaultInfo {
+	if model := config.ResolveModel("", ""); model != "" {
+		return DefaultInfo{Value: model, Source: config.ModelEnvVar}
+	}
+
+	layers, err := config.SettingsLayers(repoRoot)
 	if err == nil {
-		info.Claude.Version = string(output)
+		// Highest-precedence layer wins
+		for i := len(layers) - 1; i >= 0; i-- {
+			settings, err := config.LoadFile(layers[i].Path)
+			if err != nil {
+				continue
+			}
+			if model, ok := settings["model"].(string); ok && model != "" {
+				return DefaultInfo{Value: model, Source: layers[i].Name + " settings"}
+			}
+		}
 	}
+	return DefaultInfo{Value: "agent default", Source: "default"}
+}
 
-	return info
+// getRepositoryInfo checks workspaces and the Claude configuration in repoRoot.
+func getRepositoryInfo(repoRoot string) *RepositoryInfo {
+	repo := &RepositoryInfo{Root: repoRoot}
+
+	if branch, err := git.GetCurrentBranch(repoRoot); err == nil {
+		repo.Branch = branch
+	}
+
+	if workspaces, err := workspace.DiscoverWorkspaces(repoRoot); err != nil {
+		repo.Problems = append(repo.Problems, fmt.Sprintf("cannot list workspaces: %v", err))
+	} else {
+		for _, ws := range workspaces {
+			if ws.IsManaged() {
+				repo.Workspaces++
+			}
+			if _, err := os.Stat(ws.Path); os.IsNotExist(err) {
+				repo.Problems = append(repo.Problems, fmt.Sprintf("workspace %s is missing at %s (run git worktree prune)", ws.Branch, ws.Path))
+			}
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(repoRoot, "CLAUDE.md")); err == nil {
+		repo.ClaudeMD = true
+	}
+
+	manifestPath := filepath.Join(repoRoot, ".claude", setup.ManifestFile)
+	if data, err := os.ReadFile(manifestPath); err == nil { //nolint:gosec // Path is inside the repository
+		var manifest setup.Manifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			repo.Problems = append(repo.Problems, fmt.Sprintf("invalid %s: %v", manifestPath, err))
+		} else {
+			repo.Installed = true
+			repo.Profile = manifest.Profile
+		}
+	}
+
+	if layers, err := config.SettingsLayers(repoRoot); err == nil {
+		for _, layer := range layers {
+			if _, err := config.LoadFile(layer.Path); err != nil {
+				repo.Problems = append(repo.Problems, err.Error())
+			}
+		}
+	}
+
+	return repo
 }
 
 func printStatus(info StatusInfo) {
-	fmt.Println("\n  Claude Code")
+	fmt.Println("\n  Agents")
 	fmt.Println("  " + "----------------------------------------")
-	if info.Claude.Installed {
-		fmt.Print("  Status:   ")
-		fmt.Println("installed")
-		version := info.Claude.Version
+	for _, agent := range info.Agents {
+		if !agent.Installed {
+			fmt.Printf("  %-14s not installed\n", agent.Name)
+			continue
+		}
+		version := agent.Version
 		if version == "" {
-			version = "unknown"
+			version = "unknown version"
+		}
+		fmt.Printf("  %-14s %s (%s)\n", agent.Name, version, agent.Path)
+	}
+
+	fmt.Println("\n  Defaults")
+	fmt.Println("  " + "----------------------------------------")
+	for _, key := range []string{"model", "templates"} {
+		def := info.Defaults[key]
+		fmt.Printf("  %-14s %s (%s)\n", key, def.Value, def.Source)
+	}
+
+	if repo := info.Repository; repo != nil {
+		fmt.Println("\n  Repository")
+		fmt.Println("  " + "----------------------------------------")
+		fmt.Printf("  Root:       %s\n", repo.Root)
+		if repo.Branch != "" {
+			fmt.Printf("  Branch:     %s\n", repo.Branch)
+		}
+		fmt.Printf("  Workspaces: %d\n", repo.Workspaces)
+		fmt.Printf("  CLAUDE.md:  %s\n", presence(repo.ClaudeMD))
+		switch {
+		case repo.Installed && repo.Profile != "":
+			fmt.Printf("  agentctl:   initialized (profile %s)\n", repo.Profile)
+		case repo.Installed:
+			fmt.Println("  agentctl:   initialized")
+		default:
+			fmt.Println("  agentctl:   not initialized (run agentctl init)")
+		}
+		for _, problem := range repo.Problems {
+			fmt.Printf("  ✗ %s\n", problem)
 		}
-		fmt.Printf("  Version:  %s\n", version)
-		fmt.Printf("  Path:     %s\n", info.Claude.Path)
-	} else {
-		fmt.Print("  Status:   ")
-		fmt.Println("not installed")
 	}
 	fmt.Println()
 }
+
+func presence(ok bool) string {
+	if ok {
+		return "present"
+	}
+	return "missing"
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,11 @@
+package cli
+
+import (
+	"github.com/ryantking/agentctl/internal/cli/trace"
+	"github.com/spf13/cobra"
+)
+
+// NewTraceCmd creates the trace command group.
+func NewTraceCmd() *cobra.Command {
+	return trace.NewTraceCmd()
+}
@@ -0,0 +1,56 @@
+package trace
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/trace"
+	"github.com/spf13/cobra"
+)
+
+// NewTraceListCmd creates the trace list command.
+func NewTraceListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recorded traces",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			runs, err := trace.List()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				data := make([]map[string]interface{}, len(runs))
+				for i, run := range runs {
+					data[i] = map[string]interface{}{
+						"id":          run.ID,
+						"command":     run.Command,
+						"start":       run.Start,
+						"duration_ns": run.Duration(),
+						"spans":       len(run.Spans),
+					}
+				}
+				return output.WriteJSON(data)
+			}
+
+			if len(runs) == 0 {
+				fmt.Println("No traces recorded. Run any command with --trace to record one.")
+				return nil
+			}
+
+			for _, run := range runs {
+				fmt.Printf("%-24s %-20s %8s  %s\n", run.ID, run.Start.Local().Format("2006-01-02 15:04:05"), formatDuration(run.Duration()), run.Command)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,108 @@
+package trace
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/trace"
+	"github.com/spf13/cobra"
+)
+
+// barWidth is the number of columns used for the waterfall timeline.
+const barWidth = 40
+
+// NewTraceShowCmd creates the trace show command.
+func NewTraceShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show [id]",
+		Short: "Show a trace as a waterfall",
+		Long: `Renders the spans of a traced run as a waterfall, showing when each
+operation started relative to the run and how long it took.
+
+The ID may be a unique prefix. Without an ID the most recent trace is shown.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			id := ""
+			if len(args) > 0 {
+				id = args[0]
+			}
+
+			run, err := trace.Load(id)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.WriteJSON(run)
+			}
+
+			printWaterfall(run)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// printWaterfall prints each span with its offset, duration, and a timeline bar.
+func printWaterfall(run *trace.Run) {
+	total := run.Duration()
+	fmt.Printf("Trace %s  %s  (%s, %d spans)\n\n", run.ID, run.Command, formatDuration(total), len(run.Spans))
+
+	for _, node := range run.Tree() {
+		offset := node.Start.Sub(run.Start)
+		name := strings.Repeat("  ", node.Depth) + node.Name
+		if node.Error != "" {
+			name += "  ✗ " + firstLine(node.Error)
+		}
+		fmt.Printf("%8s %8s  %-*s  %-7s %s\n",
+			formatDuration(offset), formatDuration(node.Duration),
+			barWidth, bar(offset, node.Duration, total),
+			node.Kind, name)
+	}
+}
+
+// bar draws a span's position within the run as a fixed-width timeline.
+func bar(offset, duration, total time.Duration) string {
+	if total <= 0 {
+		return strings.Repeat(" ", barWidth)
+	}
+	start := int(int64(offset) * barWidth / int64(total))
+	width := int(int64(duration) * barWidth / int64(total))
+	if width < 1 {
+		width = 1
+	}
+	if start >= barWidth {
+		start = barWidth - 1
+	}
+	if start+width > barWidth {
+		width = barWidth - start
+	}
+	return strings.Repeat(" ", start) + strings.Repeat("█", width) + strings.Repeat(" ", barWidth-start-width)
+}
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return fmt.Sprintf("%.2fs", d.Seconds())
+	case d >= time.Millisecond:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	default:
+		return fmt.Sprintf("%dµs", d.Microseconds())
+	}
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
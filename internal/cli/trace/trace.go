@@ -0,0 +1,26 @@
+// Package trace provides CLI commands for inspecting recorded run traces.
+package trace
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewTraceCmd creates the trace command group.
+func NewTraceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trace",
+		Short: "Inspect timing traces of agentctl runs",
+		Long: `Commands for inspecting traces recorded with --trace (or AGENTCTL_TRACE=1).
+Each traced run gets an ID and records nested timing spans for the command,
+agent calls, MCP requests, and git subprocesses.`,
+	}
+
+	cmd.PersistentFlags().BoolP("json", "j", false, "Output result as JSON")
+
+	cmd.AddCommand(
+		NewTraceShowCmd(),
+		NewTraceListCmd(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/setup"
+	"github.com/spf13/cobra"
+)
+
+// NewUninstallCmd creates the uninstall command.
+func NewUninstallCmd() *cobra.Command {
+	var globalInstall, force, dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove what agentctl init installed",
+		Long: `Remove everything agentctl init installed, using the manifest init writes to
+.claude/agentctl-manifest.json: agents, skills, CLAUDE.md, settings it merged
+into settings.json, MCP servers it added to .mcp.json, and .gitignore entries.
+
+Files edited since they were installed are kept unless --force is given.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			var target string
+			var err error
+
+			if globalInstall {
+				home, err := os.UserHomeDir()
+				if err != nil {
+					output.Errorf("failed to get home directory: %v", err)
+					return err
+				}
+				target = filepath.Join(home, ".claude")
+			} else {
+				target, err = git.GetRepoRoot()
+				if err != nil {
+					output.Errorf("%v\n\nRun from inside a git repository or use --global", err)
+					return err
+				}
+			}
+
+			manager, err := setup.NewManager(target)
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+
+			if err := manager.Uninstall(dryRun, force); err != nil {
+				output.Error(err)
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&globalInstall, "global", "g", false, "Uninstall from $HOME/.claude instead of current repository")
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Also remove files modified since install")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Show what would be removed without changing anything")
+
+	return cmd
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/setup"
+	"github.com/ryantking/agentctl/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+// NewUpgradeCmd creates the upgrade command.
+func NewUpgradeCmd() *cobra.Command {
+	var globalInstall, dryRun, backup bool
+	var templateSource string
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade installed templates to the current version",
+		Long: `Upgrade the agents, skills, and CLAUDE.md installed by agentctl init to the
+templates bundled with this version of agentctl.
+
+Files you have not edited are replaced. Edited files are merged three ways
+(the template as installed, your version, and the new template); conflicting
+changes are left marked in the file for you to resolve. New templates are
+installed, and new settings and MCP servers are merged as init does.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if templateSource == "" {
+				templateSource = os.Getenv(templates.EnvVar)
+			}
+			if templateSource != "" {
+				if err := templates.Override(templateSource); err != nil {
+					output.Error(err)
+					return err
+				}
+			}
+
+			var target string
+			var err error
+
+			if globalInstall {
+				home, err := os.UserHomeDir()
+				if err != nil {
+					output.Errorf("failed to get home directory: %v", err)
+					return err
+				}
+				target = filepath.Join(home, ".claude")
+			} else {
+				target, err = git.GetRepoRoot()
+				if err != nil {
+					output.Errorf("%v\n\nRun from inside a git repository or use --global", err)
+					return err
+				}
+			}
+
+			manager, err := setup.NewManager(target)
+			if err != nil {
+				output.Error(err)
+				return err
+			}
+
+			manager.SetBackup(config.ResolveBackup(cmd.Flags().Changed("backup"), backup))
+
+			if err := manager.Upgrade(dryRun); err != nil {
+				output.Error(err)
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&globalInstall, "global", "g", false, "Upgrade $HOME/.claude instead of current repository")
+	cmd.Flags().BoolVar(&backup, "backup", false, "Save overwritten files to .claude/.agentctl-backups/<timestamp>/ (defaults to $AGENTCTL_BACKUP)")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Show what would change without writing anything")
+	cmd.Flags().StringVar(&templateSource, "templates", "", "Directory or git URL of templates overriding the bundled ones (defaults to $AGENTCTL_TEMPLATES)")
+
+	return cmd
+}
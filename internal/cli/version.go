@@ -1,8 +1,12 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/release"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +22,9 @@ var (
 	}
 )
 
+// releaseCheckTimeout bounds GitHub release queries.
+const releaseCheckTimeout = 30 * time.Second
+
 // SetVersion sets the version information.
 func SetVersion(version, commit, date string) {
 	versionInfo.version = version
@@ -27,13 +34,64 @@ func SetVersion(version, commit, date string) {
 
 // NewVersionCmd creates the version command.
 func NewVersionCmd() *cobra.Command {
+	var check, jsonMode bool
+
 	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Show the current version",
+		Long: `Show the current version, commit, and build date.
+
+Use --check to query GitHub for a newer release (install it with agentctl self-update).`,
 		RunE: func(_ *cobra.Command, _ []string) error {
+			info := map[string]interface{}{
+				"version": versionInfo.version,
+				"commit":  versionInfo.commit,
+				"date":    versionInfo.date,
+			}
+
+			var latest *release.Release
+			if check {
+				ctx, cancel := context.WithTimeout(context.Background(), releaseCheckTimeout)
+				defer cancel()
+
+				var err error
+				latest, err = release.Latest(ctx)
+				if err != nil {
+					if jsonMode {
+						return output.ErrorJSON(err)
+					}
+					output.Error(err)
+					return err
+				}
+				info["latest"] = latest.Version()
+				info["update_available"] = release.IsNewer(versionInfo.version, latest.Tag)
+			}
+
+			if jsonMode {
+				return output.WriteJSON(info)
+			}
+
 			fmt.Printf("agentctl %s\n", versionInfo.version)
+			fmt.Printf("  commit: %s\n", versionInfo.commit)
+			fmt.Printf("  built:  %s\n", versionInfo.date)
+
+			if latest != nil {
+				switch {
+				case release.IsNewer(versionInfo.version, latest.Tag):
+					fmt.Printf("\nA newer version is available: %s (%s)\n", latest.Version(), latest.URL)
+					fmt.Println("Run 'agentctl self-update' to install it.")
+				case versionInfo.version == "dev":
+					fmt.Printf("\nLatest release is %s (development build, not compared)\n", latest.Version())
+				default:
+					fmt.Println("\nagentctl is up to date.")
+				}
+			}
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&check, "check", false, "Check GitHub for a newer release")
+	cmd.Flags().BoolVarP(&jsonMode, "json", "j", false, "Output in JSON format")
+
 	return cmd
 }
@@ -3,6 +3,7 @@ package workspace
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/ryantking/agentctl/internal/output"
 	"github.com/ryantking/agentctl/internal/workspace"
@@ -11,10 +12,18 @@ import (
 
 // NewWorkspaceCleanCmd creates the workspace clean command.
 func NewWorkspaceCleanCmd() *cobra.Command {
+	var merged, dryRun bool
+	var mergedInto string
+
 	cmd := &cobra.Command{
 		Use:   "clean",
 		Short: "Remove all clean workspaces",
-		Long:  "Removes all workspaces that have no uncommitted changes. Useful for cleanup after completing work.",
+		Long: `Removes all workspaces that have no uncommitted changes. Useful for cleanup after completing work.
+
+With --merged, workspaces are only removed once every commit on their branch
+is in the base branch (the main worktree's branch, or --merged-into), so
+finished-but-unmerged work is kept. Rebased and cherry-picked commits count
+as merged. Locked workspaces are never removed.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			jsonMode, _ := cmd.Flags().GetBool("json")
 
@@ -27,7 +36,12 @@ func NewWorkspaceCleanCmd() *cobra.Command {
 				return err
 			}
 
-			removed, err := manager.CleanWorkspaces(true)
+			opts := workspace.CleanOptions{MergedInto: mergedInto, DryRun: dryRun}
+			if merged && opts.MergedInto == "" {
+				opts.MergedInto = manager.DefaultBaseBranch()
+			}
+
+			result, err := manager.CleanWorkspaces(opts)
 			if err != nil {
 				if jsonMode {
 					return output.ErrorJSON(err)
@@ -36,30 +50,42 @@ func NewWorkspaceCleanCmd() *cobra.Command {
 				return err
 			}
 
-			if len(removed) == 0 {
-				if !jsonMode {
-					fmt.Println("No clean workspaces to remove")
-				}
-				if jsonMode {
-					return output.SuccessJSON(map[string]interface{}{
-						"removed": []string{},
-					})
-				}
-				return nil
+			if jsonMode {
+				return output.SuccessJSON(map[string]interface{}{
+					"removed": result.Removed,
+					"skipped": result.Skipped,
+					"dry_run": dryRun,
+				})
 			}
 
-			data := map[string]interface{}{
-				"removed": removed,
+			verb := "Removed"
+			if dryRun {
+				verb = "Would remove"
 			}
-
-			if jsonMode {
-				return output.SuccessJSON(data)
+			if len(result.Removed) == 0 {
+				fmt.Println("No clean workspaces to remove")
+			} else {
+				fmt.Printf("%s %d workspace(s)\n", verb, len(result.Removed))
+				for _, branch := range result.Removed {
+					fmt.Printf("  - %s\n", branch)
+				}
 			}
 
-			fmt.Printf("Removed %d workspace(s)\n", len(removed))
+			skipped := make([]string, 0, len(result.Skipped))
+			for branch := range result.Skipped {
+				skipped = append(skipped, branch)
+			}
+			sort.Strings(skipped)
+			for _, branch := range skipped {
+				fmt.Printf("  • %s kept: %s\n", branch, result.Skipped[branch])
+			}
 			return nil
 		},
 	}
 
+	cmd.Flags().BoolVar(&merged, "merged", false, "Only remove workspaces whose branch is merged into the main worktree's branch")
+	cmd.Flags().StringVar(&mergedInto, "merged-into", "", "Only remove workspaces whose branch is merged into this branch (implies --merged)")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Show what would be removed")
+
 	return cmd
 }
@@ -0,0 +1,189 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspaceConfigCmd creates the workspace config command group.
+func NewWorkspaceConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage per-workspace hook policy",
+		Long: `Per-workspace settings controlling which agentctl hooks act in a checkout.
+Settings are stored in the worktree's private git directory, so they are never
+committed and are removed along with the workspace.
+
+Keys:
+  hooks.autocommit  Allow post-edit/post-write to auto-commit (default true)
+  hooks.notify      Allow notify-* hooks to send notifications (default true)
+
+Commands act on the current checkout unless --workspace names a branch.
+
+Example (disable autocommit in the main checkout):
+  agentctl workspace config set hooks.autocommit false`,
+	}
+
+	cmd.PersistentFlags().StringP("workspace", "w", "", "Branch of the workspace to configure (defaults to the current checkout)")
+	_ = cmd.RegisterFlagCompletionFunc("workspace", func(c *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completeWorkspaceNames(c, nil, toComplete)
+	})
+
+	cmd.AddCommand(
+		NewWorkspaceConfigSetCmd(),
+		NewWorkspaceConfigUnsetCmd(),
+		NewWorkspaceConfigListCmd(),
+	)
+
+	return cmd
+}
+
+// NewWorkspaceConfigSetCmd creates the workspace config set command.
+func NewWorkspaceConfigSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "set <key> <value>",
+		Short:     "Set a workspace config value",
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: workspace.ConfigKeys(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateWorkspaceConfig(cmd, args[0], func(cfg *workspace.Config) error {
+				return cfg.Set(args[0], args[1])
+			})
+		},
+	}
+
+	return cmd
+}
+
+// NewWorkspaceConfigUnsetCmd creates the workspace config unset command.
+func NewWorkspaceConfigUnsetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "unset <key>",
+		Short:     "Reset a workspace config value to its default",
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: workspace.ConfigKeys(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateWorkspaceConfig(cmd, args[0], func(cfg *workspace.Config) error {
+				return cfg.Unset(args[0])
+			})
+		},
+	}
+
+	return cmd
+}
+
+// NewWorkspaceConfigListCmd creates the workspace config list command.
+func NewWorkspaceConfigListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Show effective workspace config values",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			path, err := configTargetPath(cmd)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			cfg, err := workspace.LoadConfig(path)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			values := cfg.Values()
+			if jsonMode {
+				return output.SuccessJSON(map[string]interface{}{
+					"path":   path,
+					"config": values,
+				})
+			}
+
+			for _, key := range workspace.ConfigKeys() {
+				fmt.Printf("%s=%t\n", key, values[key])
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// updateWorkspaceConfig loads, modifies, and saves the target workspace config.
+func updateWorkspaceConfig(cmd *cobra.Command, key string, update func(*workspace.Config) error) error {
+	jsonMode, _ := cmd.Flags().GetBool("json")
+
+	path, err := configTargetPath(cmd)
+	if err != nil {
+		if jsonMode {
+			return output.ErrorJSON(err)
+		}
+		output.Error(err)
+		return err
+	}
+
+	cfg, err := workspace.LoadConfig(path)
+	if err != nil {
+		if jsonMode {
+			return output.ErrorJSON(err)
+		}
+		output.Error(err)
+		return err
+	}
+
+	if err := update(cfg); err != nil {
+		if jsonMode {
+			return output.ErrorJSON(err)
+		}
+		output.Error(err)
+		return err
+	}
+
+	if err := workspace.SaveConfig(path, cfg); err != nil {
+		if jsonMode {
+			return output.ErrorJSON(err)
+		}
+		output.Error(err)
+		return err
+	}
+
+	if jsonMode {
+		return output.SuccessJSON(map[string]interface{}{
+			"path":   path,
+			"config": cfg.Values(),
+		})
+	}
+
+	fmt.Printf("Updated %s for %s\n", key, path)
+	return nil
+}
+
+// configTargetPath resolves --workspace to a worktree path, defaulting to the working directory.
+func configTargetPath(cmd *cobra.Command) (string, error) {
+	branch, _ := cmd.Flags().GetString("workspace")
+	if branch == "" {
+		return os.Getwd()
+	}
+
+	manager, err := workspace.NewManager()
+	if err != nil {
+		return "", err
+	}
+	ws, err := manager.GetWorkspace(branch)
+	if err != nil {
+		return "", err
+	}
+	return ws.Path, nil
+}
@@ -2,7 +2,7 @@ package workspace
 
 import (
 	"fmt"
-	"os"
+	"log/slog"
 
 	"github.com/ryantking/agentctl/internal/context"
 	"github.com/ryantking/agentctl/internal/output"
@@ -13,12 +13,16 @@ import (
 // NewWorkspaceCreateCmd creates the workspace create command.
 func NewWorkspaceCreateCmd() *cobra.Command {
 	var baseBranch string
+	var sparsePaths []string
 
 	cmd := &cobra.Command{
 		Use:   "create <branch>",
 		Short: "Create a new workspace with git worktree",
 		Long: `Create a new workspace at ~/.claude/workspaces/<repo>/<branch>/
-and copies necessary context files (CLAUDE.md, settings.local.json, .mcp.json).`,
+and copies necessary context files (CLAUDE.md, settings.local.json, .mcp.json).
+
+Use --sparse to check out only the listed directories (plus files at the
+repository root) via git sparse-checkout, which keeps creation fast in large monorepos.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			jsonMode, _ := cmd.Flags().GetBool("json")
@@ -33,7 +37,7 @@ and copies necessary context files (CLAUDE.md, settings.local.json, .mcp.json).`
 				return err
 			}
 
-			ws, err := manager.CreateWorkspace(branch, baseBranch)
+			ws, err := manager.CreateWorkspace(branch, baseBranch, sparsePaths)
 			if err != nil {
 				if jsonMode {
 					return output.ErrorJSON(err)
@@ -46,9 +50,7 @@ and copies necessary context files (CLAUDE.md, settings.local.json, .mcp.json).`
 			copiedFiles, err := context.CopyClaudeContext(ws.Path, ws.RepoRoot)
 			if err != nil {
 				// Non-fatal error, just log it
-				if !jsonMode {
-					fmt.Fprintf(os.Stderr, "Warning: failed to copy context files: %v\n", err)
-				}
+				slog.Warn("failed to copy context files", "workspace", ws.Path, "err", err)
 			}
 
 			data := map[string]interface{}{
@@ -56,12 +58,18 @@ and copies necessary context files (CLAUDE.md, settings.local.json, .mcp.json).`
 				"branch": ws.Branch,
 				"commit": ws.Commit,
 			}
+			if len(sparsePaths) > 0 {
+				data["sparse"] = sparsePaths
+			}
 
 			if jsonMode {
 				return output.SuccessJSON(data)
 			}
 
 			fmt.Printf("Created workspace: %s\n", ws.Path)
+			if len(sparsePaths) > 0 {
+				fmt.Printf("Sparse checkout: %v\n", sparsePaths)
+			}
 			if len(copiedFiles) > 0 {
 				fmt.Printf("Copied context: %v\n", copiedFiles)
 			}
@@ -71,6 +79,7 @@ and copies necessary context files (CLAUDE.md, settings.local.json, .mcp.json).`
 	}
 
 	cmd.Flags().StringVarP(&baseBranch, "base", "b", "", "Base branch to create from (defaults to current branch)")
+	cmd.Flags().StringSliceVar(&sparsePaths, "sparse", nil, "Limit the checkout to these directories (comma-separated or repeated)")
 
 	return cmd
 }
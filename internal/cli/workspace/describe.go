@@ -0,0 +1,145 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/agent"
+	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/output"
+	"github.com/ryantking/agentctl/internal/ui"
+	"github.com/ryantking/agentctl/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+const describePrompt = `Write a pull request description for the branch %q, based on its commit
+messages and the diff against %q on stdin.
+
+Use this markdown structure:
+
+## Summary
+One or two sentences on what the change does.
+
+## Changes
+A bulleted list of the notable changes, grouped by area.
+
+## Why
+The motivation, taken from the commit messages where possible.
+
+## Testing
+How the change was or should be tested. Say so if the diff adds no tests.
+
+Be concise and factual. Output only the markdown.`
+
+// NewWorkspaceDescribeCmd creates the workspace describe command.
+func NewWorkspaceDescribeCmd() *cobra.Command {
+	var base, model string
+
+	cmd := &cobra.Command{
+		Use:   "describe [branch]",
+		Short: "Generate a PR description from a workspace's commits and diff",
+		Long: `Feeds the workspace's commit messages and its diff against the base branch
+to the Claude CLI and prints a markdown summary (what changed, why, testing
+notes) suitable for a pull request body or issue tracker.
+
+The base defaults to the branch checked out in the main worktree. If no branch
+is provided, opens an interactive picker.`,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeWorkspaceNames,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jsonMode, _ := cmd.Flags().GetBool("json")
+
+			manager, err := workspace.NewManager()
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			workspaces, err := manager.ListWorkspaces(true)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			branch, err := ui.GetWorkspaceArg(args, workspaces)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			ws, err := manager.GetWorkspace(branch)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if base == "" {
+				base = manager.DefaultBaseBranch()
+			}
+
+			log, err := manager.GetWorkspaceLog(ws, base)
+			if err == nil && log == "" {
+				err = fmt.Errorf("%s has no commits that are not on %s", branch, base)
+			}
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			diff, err := manager.GetWorkspaceDiff(ws, base)
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			description, err := agent.Run(context.Background(), agent.Request{
+				Name:   "describe " + branch,
+				Prompt: fmt.Sprintf(describePrompt, branch, base),
+				Input:  "Commits:\n\n" + log + "\n\nDiff:\n\n" + diff,
+				Model:  config.ResolveModel(model, ""),
+				Dir:    ws.Path,
+			})
+			if err != nil {
+				if jsonMode {
+					return output.ErrorJSON(err)
+				}
+				output.Error(err)
+				return err
+			}
+
+			if jsonMode {
+				return output.SuccessJSON(map[string]interface{}{
+					"branch":      branch,
+					"base":        base,
+					"description": description,
+				})
+			}
+
+			fmt.Println(description)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&base, "base", "b", "", "Branch to compare against (defaults to the main worktree's branch)")
+	cmd.Flags().StringVarP(&model, "model", "m", "", "Model used to write the description (defaults to $AGENTCTL_MODEL, then the Claude CLI default)")
+
+	return cmd
+}
@@ -87,13 +87,22 @@ func NewWorkspaceListCmd() *cobra.Command { //nolint:gocyclo // Complex command
 					marker = "*"
 				}
 
+				var flags string
+				if w.Locked {
+					flags += " [locked]"
+				}
+				if w.Prunable {
+					flags += " [prunable]"
+				}
+
 				// Format: * branch-name    ✓ clean    abc1234
-				_, _ = fmt.Fprintf(os.Stdout, "%s %-30s %s %-20s %s\n",
+				_, _ = fmt.Fprintf(os.Stdout, "%s %-30s %s %-20s %s%s\n",
 					marker,
 					branch,
 					statusIcon,
 					status,
 					w.Commit,
+					flags,
 				)
 			}
 
@@ -119,5 +128,7 @@ func workspaceToMapWithoutPath(w workspace.Workspace) map[string]interface{} {
 		"is_managed": w.IsManaged(),
 		"is_clean":   isClean,
 		"status":     status,
+		"locked":     w.Locked,
+		"prunable":   w.Prunable,
 	}
 }
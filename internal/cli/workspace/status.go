@@ -2,6 +2,7 @@ package workspace
 
 import (
 	"fmt"
+	"log/slog"
 
 	"github.com/ryantking/agentctl/internal/output"
 	"github.com/ryantking/agentctl/internal/ui"
@@ -11,10 +12,12 @@ import (
 
 // NewWorkspaceStatusCmd creates the workspace status command.
 func NewWorkspaceStatusCmd() *cobra.Command {
+	var all, fetch bool
+
 	cmd := &cobra.Command{
 		Use:               "status [branch]",
 		Short:             "Show detailed workspace status",
-		Long:              "Displays status information including uncommitted changes, ahead/behind status relative to remote, and other details. If no branch is provided, opens an interactive picker.",
+		Long:              "Displays status information including uncommitted changes, ahead/behind status relative to the branch's upstream, and other details. Use --fetch to update remote branches first. If no branch is provided, opens an interactive picker.",
 		Args:              cobra.MaximumNArgs(1),
 		ValidArgsFunction: completeWorkspaceNames,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -38,6 +41,17 @@ func NewWorkspaceStatusCmd() *cobra.Command {
 				return err
 			}
 
+			if fetch {
+				if err := manager.Fetch(); err != nil {
+					// Stale remote state is still useful; report and continue
+					slog.Warn("fetch failed, showing last known remote state", "err", err)
+				}
+			}
+
+			if all {
+				return printAllWorkspaceStatus(manager, workspaces, jsonMode)
+			}
+
 			branch, err := ui.GetWorkspaceArg(args, workspaces)
 			if err != nil {
 				if jsonMode {
@@ -69,21 +83,63 @@ func NewWorkspaceStatusCmd() *cobra.Command {
 				return output.SuccessJSON(statusInfo)
 			}
 
-			// Human-readable output
-			fmt.Printf("\nWorkspace: %v\n", statusInfo["branch"])
-			fmt.Printf("Path:      %v\n", statusInfo["path"])
-			fmt.Printf("Commit:    %v\n", statusInfo["commit"])
-			fmt.Printf("Status:    %v\n", statusInfo["status"])
-
-			if aheadBehind, ok := statusInfo["ahead_behind"].(map[string]int); ok {
-				fmt.Printf("Sync:      %d ahead, %d behind origin\n", aheadBehind["ahead"], aheadBehind["behind"])
-			}
-
+			printWorkspaceStatus(statusInfo)
 			fmt.Println()
 
 			return nil
 		},
 	}
 
+	cmd.Flags().BoolVarP(&all, "all", "a", false, "Show status for every managed workspace")
+	cmd.Flags().BoolVarP(&fetch, "fetch", "f", false, "Fetch and prune origin before comparing with upstream")
+
 	return cmd
 }
+
+// printAllWorkspaceStatus prints status for every managed workspace.
+func printAllWorkspaceStatus(manager *workspace.WorkspaceManager, workspaces []workspace.Workspace, jsonMode bool) error {
+	statuses := make([]map[string]interface{}, 0, len(workspaces))
+	for i := range workspaces {
+		statusInfo, err := manager.GetWorkspaceStatus(&workspaces[i])
+		if err != nil {
+			if jsonMode {
+				return output.ErrorJSON(err)
+			}
+			output.Error(err)
+			return err
+		}
+		statuses = append(statuses, statusInfo)
+	}
+
+	if jsonMode {
+		return output.SuccessJSON(statuses)
+	}
+
+	if len(statuses) == 0 {
+		fmt.Print("\n  No workspaces found.\n\n")
+		return nil
+	}
+
+	for _, statusInfo := range statuses {
+		printWorkspaceStatus(statusInfo)
+	}
+	fmt.Println()
+	return nil
+}
+
+// printWorkspaceStatus prints the human-readable status block for one workspace.
+func printWorkspaceStatus(statusInfo map[string]interface{}) {
+	fmt.Printf("\nWorkspace: %v\n", statusInfo["branch"])
+	fmt.Printf("Path:      %v\n", statusInfo["path"])
+	fmt.Printf("Commit:    %v\n", statusInfo["commit"])
+	fmt.Printf("Status:    %v\n", statusInfo["status"])
+
+	switch aheadBehind, ok := statusInfo["ahead_behind"].(map[string]int); {
+	case statusInfo["upstream_gone"] == true:
+		fmt.Printf("Sync:      upstream %v is gone (deleted on the remote)\n", statusInfo["upstream"])
+	case ok:
+		fmt.Printf("Sync:      %d ahead, %d behind %v\n", aheadBehind["ahead"], aheadBehind["behind"], statusInfo["upstream"])
+	case statusInfo["branch"] != "":
+		fmt.Println("Sync:      no upstream branch")
+	}
+}
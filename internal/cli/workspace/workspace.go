@@ -20,8 +20,10 @@ func NewWorkspaceCmd() *cobra.Command {
 		NewWorkspaceListCmd(),
 		NewWorkspaceShowCmd(),
 		NewWorkspaceStatusCmd(),
+		NewWorkspaceDescribeCmd(),
 		NewWorkspaceDeleteCmd(),
 		NewWorkspaceCleanCmd(),
+		NewWorkspaceConfigCmd(),
 	)
 
 	return cmd
@@ -0,0 +1,19 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+// BackupEnvVar is the environment variable that turns on backups by default.
+const BackupEnvVar = "AGENTCTL_BACKUP"
+
+// ResolveBackup decides whether a run backs up files it overwrites.
+// Precedence: an explicitly set --backup flag, then AGENTCTL_BACKUP, then off.
+func ResolveBackup(flagSet, flagValue bool) bool {
+	if flagSet {
+		return flagValue
+	}
+	enabled, err := strconv.ParseBool(os.Getenv(BackupEnvVar))
+	return err == nil && enabled
+}
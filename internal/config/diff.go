@@ -0,0 +1,159 @@
+package config
+
+import (
+	"reflect"
+)
+
+// Diff returns the parts of after that are not in before: new keys, new
+// list items, and changed scalars. Applying Subtract with the result to
+// after yields settings without anything a merge contributed.
+// Hook entries are compared per hook, so a hook Merge added to an existing
+// matcher is recorded on its own.
+func Diff(after, before map[string]interface{}) map[string]interface{} {
+	return diffMaps(after, before, "")
+}
+
+func diffMaps(after, before map[string]interface{}, path string) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range after {
+		keyPath := joinPath(path, key)
+		existing, exists := before[key]
+		switch {
+		case !exists:
+			result[key] = value
+		case isMap(value) && isMap(existing):
+			if nested := diffMaps(value.(map[string]interface{}), existing.(map[string]interface{}), keyPath); len(nested) > 0 {
+				result[key] = nested
+			}
+		case isSlice(value) && isSlice(existing):
+			if added := diffLists(value.([]interface{}), existing.([]interface{}), keyPath); len(added) > 0 {
+				result[key] = added
+			}
+		case !reflect.DeepEqual(value, existing):
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func diffLists(after, before []interface{}, path string) []interface{} {
+	var added []interface{}
+	for _, item := range after {
+		if containsValue(before, item) {
+			continue
+		}
+		entry, ok := item.(map[string]interface{})
+		if !isHookEventPath(path) || !ok {
+			added = append(added, item)
+			continue
+		}
+
+		idx := findHookEntry(before, entry["matcher"])
+		if idx == -1 {
+			added = append(added, item)
+			continue
+		}
+		beforeHooks, _ := before[idx].(map[string]interface{})["hooks"].([]interface{})
+		afterHooks, _ := entry["hooks"].([]interface{})
+		if newHooks := diffLists(afterHooks, beforeHooks, ""); len(newHooks) > 0 {
+			partial := map[string]interface{}{"hooks": newHooks}
+			if matcher, ok := entry["matcher"]; ok {
+				partial["matcher"] = matcher
+			}
+			added = append(added, partial)
+		}
+	}
+	return added
+}
+
+// Subtract removes from settings every key, list item, and scalar recorded in
+// remove, pruning maps and lists left empty. Scalars are only removed while
+// they still hold the recorded value, so user changes are preserved.
+func Subtract(settings, remove map[string]interface{}) map[string]interface{} {
+	return subtractMaps(settings, remove, "")
+}
+
+func subtractMaps(settings, remove map[string]interface{}, path string) map[string]interface{} {
+	result := make(map[string]interface{}, len(settings))
+	for k, v := range settings {
+		result[k] = v
+	}
+
+	for key, value := range remove {
+		keyPath := joinPath(path, key)
+		existing, exists := result[key]
+		if !exists {
+			continue
+		}
+		switch {
+		case isMap(value) && isMap(existing):
+			nested := subtractMaps(existing.(map[string]interface{}), value.(map[string]interface{}), keyPath)
+			if len(nested) == 0 {
+				delete(result, key)
+			} else {
+				result[key] = nested
+			}
+		case isSlice(value) && isSlice(existing):
+			kept := subtractLists(existing.([]interface{}), value.([]interface{}), keyPath)
+			if len(kept) == 0 {
+				delete(result, key)
+			} else {
+				result[key] = kept
+			}
+		case reflect.DeepEqual(value, existing):
+			delete(result, key)
+		}
+	}
+	return result
+}
+
+func subtractLists(list, remove []interface{}, path string) []interface{} {
+	if !isHookEventPath(path) {
+		var kept []interface{}
+		for _, item := range list {
+			if !containsValue(remove, item) {
+				kept = append(kept, item)
+			}
+		}
+		return kept
+	}
+
+	// Hook entries: remove individual hooks from the entry with the same matcher
+	kept := make([]interface{}, 0, len(list))
+	for _, item := range list {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			if !containsValue(remove, item) {
+				kept = append(kept, item)
+			}
+			continue
+		}
+		idx := findHookEntry(remove, entry["matcher"])
+		if idx == -1 {
+			kept = append(kept, item)
+			continue
+		}
+		hooks, _ := entry["hooks"].([]interface{})
+		removeHooks, _ := remove[idx].(map[string]interface{})["hooks"].([]interface{})
+		remaining := subtractLists(hooks, removeHooks, "")
+		if len(remaining) == 0 {
+			continue
+		}
+		trimmed := make(map[string]interface{}, len(entry))
+		for k, v := range entry {
+			trimmed[k] = v
+		}
+		trimmed["hooks"] = remaining
+		kept = append(kept, trimmed)
+	}
+	return kept
+}
+
+func containsValue(list []interface{}, item interface{}) bool {
+	for _, existing := range list {
+		if reflect.DeepEqual(existing, item) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/fsutil"
+)
+
+// Settings layer names, from lowest to highest precedence.
+const (
+	LayerUser    = "user"
+	LayerProject = "project"
+	LayerLocal   = "local"
+)
+
+// Layer is a single Claude Code settings file that contributes to the
+// effective configuration.
+type Layer struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// SettingsLayers returns the settings files Claude Code reads, ordered from
+// lowest to highest precedence: ~/.claude/settings.json, then the
+// repository's .claude/settings.json and .claude/settings.local.json.
+// If repoRoot is empty only the user layer is returned.
+func SettingsLayers(repoRoot string) ([]Layer, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	layers := []Layer{
+		{Name: LayerUser, Path: filepath.Join(home, ".claude", "settings.json")},
+	}
+	if repoRoot != "" {
+		layers = append(layers,
+			Layer{Name: LayerProject, Path: filepath.Join(repoRoot, ".claude", "settings.json")},
+			Layer{Name: LayerLocal, Path: filepath.Join(repoRoot, ".claude", "settings.local.json")},
+		)
+	}
+	return layers, nil
+}
+
+// LoadFile reads a JSON settings file, returning an empty map if it does not exist.
+func LoadFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is controlled, reading settings files
+	if os.IsNotExist(err) {
+		return make(map[string]interface{}), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	settings, err := LoadJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if settings == nil {
+		settings = make(map[string]interface{})
+	}
+	return settings, nil
+}
+
+// SaveFile writes settings as indented JSON, creating parent directories as needed.
+// New local settings files, which hold machine-specific values such as
+// tokens, are readable only by the owner.
+func SaveFile(path string, settings map[string]interface{}) error {
+	data, err := SaveJSON(settings)
+	if err != nil {
+		return err
+	}
+	if err := fsutil.MkdirAll(filepath.Dir(path)); err != nil {
+		return err
+	}
+	mode := fsutil.FileMode()
+	if strings.HasSuffix(path, ".local.json") {
+		mode = fsutil.PrivateFileMode
+	}
+	return fsutil.WriteFile(path, append(data, '\n'), mode)
+}
+
+// Effective merges the given layers in order, so later layers take precedence.
+// Missing files are treated as empty. As in Claude Code, a deny rule in any
+// layer removes the matching allow and ask rules from every layer.
+func Effective(layers []Layer) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	for _, layer := range layers {
+		settings, err := LoadFile(layer.Path)
+		if err != nil {
+			return nil, err
+		}
+		result = Merge(result, settings)
+	}
+	return dropDenied(result), nil
+}
+
+// dropDenied returns settings without the permissions.allow and
+// permissions.ask rules that permissions.deny also lists. Merge only filters
+// the overlay's rules, so rules from lower layers need this pass.
+func dropDenied(settings map[string]interface{}) map[string]interface{} {
+	denied := deniedPermissions(settings)
+	if len(denied) == 0 {
+		return settings
+	}
+	permissions := make(map[string]interface{})
+	for k, v := range settings["permissions"].(map[string]interface{}) {
+		permissions[k] = v
+	}
+	for _, key := range []string{"allow", "ask"} {
+		rules, ok := permissions[key].([]interface{})
+		if !ok {
+			continue
+		}
+		kept := make([]interface{}, 0, len(rules))
+		for _, rule := range rules {
+			if !containsValue(denied, rule) {
+				kept = append(kept, rule)
+			}
+		}
+		permissions[key] = kept
+	}
+	settings["permissions"] = permissions
+	return settings
+}
+
+// SetPath sets a dotted key path (e.g. "env.DISABLE_TELEMETRY") in settings,
+// creating intermediate maps as needed.
+func SetPath(settings map[string]interface{}, key string, value interface{}) error {
+	parts := strings.Split(key, ".")
+	current := settings
+	for i, part := range parts[:len(parts)-1] {
+		next, exists := current[part]
+		if !exists {
+			child := make(map[string]interface{})
+			current[part] = child
+			current = child
+			continue
+		}
+		child, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("cannot set %s: %s is not an object", key, strings.Join(parts[:i+1], "."))
+		}
+		current = child
+	}
+	current[parts[len(parts)-1]] = value
+	return nil
+}
+
+// UnsetPath removes a dotted key path from settings.
+// Returns false if the key was not present.
+func UnsetPath(settings map[string]interface{}, key string) bool {
+	parts := strings.Split(key, ".")
+	current := settings
+	for _, part := range parts[:len(parts)-1] {
+		child, ok := current[part].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		current = child
+	}
+	last := parts[len(parts)-1]
+	if _, exists := current[last]; !exists {
+		return false
+	}
+	delete(current, last)
+	return true
+}
+
+// ParseValue interprets a command-line value as JSON when possible
+// (numbers, booleans, arrays, objects), falling back to a plain string.
+func ParseValue(raw string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(raw), &value); err == nil {
+		return value
+	}
+	return raw
+}
@@ -0,0 +1,19 @@
+package config
+
+import "os"
+
+// ModelEnvVar is the environment variable that overrides the default model.
+const ModelEnvVar = "AGENTCTL_MODEL"
+
+// ResolveModel picks the model for an agent invocation.
+// Precedence: explicit flag value, then AGENTCTL_MODEL, then the command default.
+// An empty result means the agent CLI should use its own default.
+func ResolveModel(flagValue, commandDefault string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if env := os.Getenv(ModelEnvVar); env != "" {
+		return env
+	}
+	return commandDefault
+}
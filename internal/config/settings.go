@@ -3,35 +3,60 @@ package config
 
 import (
 	"encoding/json"
-	"reflect"
+	"strings"
 )
 
 // Merge performs a deep merge of settings with intelligent array handling.
 // Strategy:
 // - Nested maps: Recursive merge
-// - Arrays: Union (deduplicate simple types)
+// - Arrays: Union preserving base order (deduplicate by value)
 // - Scalars: Overlay takes precedence
+//
+// Known Claude Code settings get schema-aware handling:
+//   - hooks.<event>: entries with the same matcher are combined, and hook
+//     commands already present are not added again
+//   - permissions.allow/ask: rules the base denies are not added
+//
+// Base rules are never removed, so merging into a settings file keeps the
+// user's entries. Effective applies deny rules across all layers.
 func Merge(base, overlay map[string]interface{}) map[string]interface{} {
+	m := merger{denied: deniedPermissions(base)}
+	return m.merge(base, overlay, "")
+}
+
+// merger carries settings-wide context through a merge.
+type merger struct {
+	denied []interface{}
+}
+
+func (m merger) merge(base, overlay map[string]interface{}, path string) map[string]interface{} {
 	result := make(map[string]interface{})
 	for k, v := range base {
 		result[k] = v
 	}
 
 	for key, value := range overlay {
+		keyPath := joinPath(path, key)
 		existing, exists := result[key]
 		switch {
+		case !exists && isMap(value):
+			// New map - merge into nothing so nested rules still apply
+			result[key] = m.merge(map[string]interface{}{}, value.(map[string]interface{}), keyPath)
+		case !exists && isSlice(value):
+			result[key] = m.mergeList(keyPath, []interface{}{}, value.([]interface{}))
 		case !exists:
 			// New key - add it
 			result[key] = value
 		case isMap(value) && isMap(existing):
 			// Both maps - recursive merge
-			result[key] = Merge(
+			result[key] = m.merge(
 				existing.(map[string]interface{}),
 				value.(map[string]interface{}),
+				keyPath,
 			)
 		case isSlice(value) && isSlice(existing):
 			// Both slices - merge with deduplication
-			result[key] = mergeLists(existing.([]interface{}), value.([]interface{}))
+			result[key] = m.mergeList(keyPath, existing.([]interface{}), value.([]interface{}))
 		default:
 			// Scalar or type mismatch - overlay wins
 			result[key] = value
@@ -41,48 +66,120 @@ func Merge(base, overlay map[string]interface{}) map[string]interface{} {
 	return result
 }
 
+func (m merger) mergeList(path string, base, overlay []interface{}) []interface{} {
+	switch {
+	case isHookEventPath(path):
+		return mergeHookEntries(base, overlay)
+	case path == "permissions.allow" || path == "permissions.ask":
+		var allowed []interface{}
+		for _, item := range overlay {
+			if !containsValue(m.denied, item) {
+				allowed = append(allowed, item)
+			}
+		}
+		overlay = allowed
+	}
+	return mergeLists(base, overlay)
+}
+
 func mergeLists(base, overlay []interface{}) []interface{} {
 	result := make([]interface{}, len(base))
 	copy(result, base)
 
 	for _, item := range overlay {
-		// For simple types, deduplicate
-		if isSimpleType(item) {
-			found := false
-			for _, existing := range result {
-				if reflect.DeepEqual(existing, item) {
-					found = true
-					break
-				}
-			}
-			if !found {
+		if !containsValue(result, item) {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// mergeHookEntries merges hook matcher entries for one event, e.g.
+// [{"matcher": "Edit", "hooks": [{"type": "command", "command": "..."}]}].
+// Overlay hooks join the base entry with the same matcher, skipping hooks it
+// already has; entries with new matchers are appended.
+func mergeHookEntries(base, overlay []interface{}) []interface{} {
+	result := make([]interface{}, len(base))
+	copy(result, base)
+
+	for _, item := range overlay {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			if !containsValue(result, item) {
 				result = append(result, item)
 			}
-		} else {
-			// For complex types, just append
+			continue
+		}
+
+		idx := findHookEntry(result, entry["matcher"])
+		if idx == -1 {
 			result = append(result, item)
+			continue
+		}
+
+		existing := result[idx].(map[string]interface{})
+		existingHooks, _ := existing["hooks"].([]interface{})
+		newHooks, _ := entry["hooks"].([]interface{})
+		combined := make(map[string]interface{}, len(existing))
+		for k, v := range existing {
+			combined[k] = v
 		}
+		combined["hooks"] = mergeLists(existingHooks, newHooks)
+		result[idx] = combined
 	}
 
 	return result
 }
 
+// findHookEntry returns the index of the hook entry with the given matcher, or -1.
+// A missing matcher and an empty one are equivalent.
+func findHookEntry(entries []interface{}, matcher interface{}) int {
+	for i, item := range entries {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if matcherString(entry["matcher"]) == matcherString(matcher) {
+			return i
+		}
+	}
+	return -1
+}
+
+func matcherString(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+// isHookEventPath reports whether path is a hooks.<event> list.
+func isHookEventPath(path string) bool {
+	parts := strings.Split(path, ".")
+	return len(parts) == 2 && parts[0] == "hooks"
+}
+
+// deniedPermissions returns the permissions.deny rules in settings.
+func deniedPermissions(settings map[string]interface{}) []interface{} {
+	permissions, _ := settings["permissions"].(map[string]interface{})
+	denied, _ := permissions["deny"].([]interface{})
+	return denied
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
 func isMap(v interface{}) bool {
 	_, ok := v.(map[string]interface{})
 	return ok
 }
 
 func isSlice(v interface{}) bool {
-	return reflect.TypeOf(v).Kind() == reflect.Slice
-}
-
-func isSimpleType(v interface{}) bool {
-	switch v.(type) {
-	case string, int, int64, float64, bool, nil:
-		return true
-	default:
-		return false
-	}
+	_, ok := v.([]interface{})
+	return ok
 }
 
 // LoadJSON loads JSON settings from bytes.
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"path/filepath"
 	"reflect"
 	"testing"
 )
@@ -108,3 +109,279 @@ func TestLoadSaveJSON(t *testing.T) {
 		t.Error("Array length doesn't match")
 	}
 }
+
+func TestSetUnsetPath(t *testing.T) {
+	settings := map[string]interface{}{
+		"env":   map[string]interface{}{"EXISTING": "1"},
+		"model": "sonnet",
+	}
+
+	if err := SetPath(settings, "env.NEW", "2"); err != nil {
+		t.Fatalf("SetPath failed: %v", err)
+	}
+	if err := SetPath(settings, "permissions.defaultMode", "plan"); err != nil {
+		t.Fatalf("SetPath failed: %v", err)
+	}
+	if err := SetPath(settings, "model.name", "x"); err == nil {
+		t.Error("Expected error when traversing a non-object value")
+	}
+
+	env := settings["env"].(map[string]interface{})
+	if env["EXISTING"] != "1" || env["NEW"] != "2" {
+		t.Errorf("Unexpected env: %v", env)
+	}
+	permissions := settings["permissions"].(map[string]interface{})
+	if permissions["defaultMode"] != "plan" {
+		t.Errorf("Expected intermediate map to be created, got %v", permissions)
+	}
+
+	if !UnsetPath(settings, "env.NEW") {
+		t.Error("UnsetPath should report removing env.NEW")
+	}
+	if UnsetPath(settings, "env.MISSING") {
+		t.Error("UnsetPath should report missing key")
+	}
+	if _, ok := env["NEW"]; ok {
+		t.Error("env.NEW should be removed")
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	if v := ParseValue("42"); v != float64(42) {
+		t.Errorf("Expected number, got %#v", v)
+	}
+	if v := ParseValue("true"); v != true {
+		t.Errorf("Expected bool, got %#v", v)
+	}
+	if v := ParseValue("sonnet"); v != "sonnet" {
+		t.Errorf("Expected string fallback, got %#v", v)
+	}
+	if v, ok := ParseValue(`["a","b"]`).([]interface{}); !ok || len(v) != 2 {
+		t.Errorf("Expected array, got %#v", v)
+	}
+}
+
+func TestEffective(t *testing.T) {
+	dir := t.TempDir()
+	project := filepath.Join(dir, "settings.json")
+	local := filepath.Join(dir, "settings.local.json")
+
+	if err := SaveFile(project, map[string]interface{}{
+		"model":       "sonnet",
+		"permissions": map[string]interface{}{"allow": []interface{}{"Edit"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveFile(local, map[string]interface{}{
+		"model":       "opus",
+		"permissions": map[string]interface{}{"allow": []interface{}{"Bash(make:*)"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	effective, err := Effective([]Layer{
+		{Name: LayerUser, Path: filepath.Join(dir, "missing.json")},
+		{Name: LayerProject, Path: project},
+		{Name: LayerLocal, Path: local},
+	})
+	if err != nil {
+		t.Fatalf("Effective failed: %v", err)
+	}
+
+	if effective["model"] != "opus" {
+		t.Errorf("Expected local layer to win, got %v", effective["model"])
+	}
+	allow := effective["permissions"].(map[string]interface{})["allow"]
+	if !reflect.DeepEqual(allow, []interface{}{"Edit", "Bash(make:*)"}) {
+		t.Errorf("Expected permission union, got %v", allow)
+	}
+}
+
+func TestDiffSubtract(t *testing.T) {
+	before := map[string]interface{}{
+		"model": "opus",
+		"permissions": map[string]interface{}{
+			"allow": []interface{}{"Bash(make:*)"},
+		},
+	}
+	template := map[string]interface{}{
+		"cleanupPeriodDays": float64(30),
+		"permissions": map[string]interface{}{
+			"allow": []interface{}{"Bash(make:*)", "Bash(git:*)"},
+		},
+	}
+	after := Merge(before, template)
+
+	added := Diff(after, before)
+	want := map[string]interface{}{
+		"cleanupPeriodDays": float64(30),
+		"permissions": map[string]interface{}{
+			"allow": []interface{}{"Bash(git:*)"},
+		},
+	}
+	if !reflect.DeepEqual(added, want) {
+		t.Errorf("Diff() = %v, want %v", added, want)
+	}
+
+	// The user changes a value the merge added; it should survive removal
+	after["cleanupPeriodDays"] = float64(7)
+	got := Subtract(after, added)
+	wantAfter := map[string]interface{}{
+		"model":             "opus",
+		"cleanupPeriodDays": float64(7),
+		"permissions": map[string]interface{}{
+			"allow": []interface{}{"Bash(make:*)"},
+		},
+	}
+	if !reflect.DeepEqual(got, wantAfter) {
+		t.Errorf("Subtract() = %v, want %v", got, wantAfter)
+	}
+
+	if empty := Subtract(template, Diff(template, nil)); len(empty) != 0 {
+		t.Errorf("Subtract(template, all) = %v, want empty", empty)
+	}
+}
+
+func hookEntry(matcher string, commands ...string) map[string]interface{} {
+	hooks := make([]interface{}, len(commands))
+	for i, command := range commands {
+		hooks[i] = map[string]interface{}{"type": "command", "command": command}
+	}
+	entry := map[string]interface{}{"hooks": hooks}
+	if matcher != "" {
+		entry["matcher"] = matcher
+	}
+	return entry
+}
+
+func TestMergeHooks(t *testing.T) {
+	base := map[string]interface{}{
+		"hooks": map[string]interface{}{
+			"PostToolUse": []interface{}{
+				hookEntry("Edit", "prettier --write"),
+				hookEntry("Bash", "audit-log"),
+			},
+			"Stop": []interface{}{
+				hookEntry("", "agentctl hook notify-stop"),
+			},
+		},
+	}
+	template := map[string]interface{}{
+		"hooks": map[string]interface{}{
+			"PostToolUse": []interface{}{
+				hookEntry("Edit", "agentctl hook post-edit"),
+				hookEntry("Write", "agentctl hook post-write"),
+			},
+			"Stop": []interface{}{
+				hookEntry("", "agentctl hook notify-stop"),
+			},
+		},
+	}
+
+	merged := Merge(base, template)
+	// Merging twice must not duplicate anything
+	merged = Merge(merged, template)
+
+	hooks := merged["hooks"].(map[string]interface{})
+	wantPostToolUse := []interface{}{
+		hookEntry("Edit", "prettier --write", "agentctl hook post-edit"),
+		hookEntry("Bash", "audit-log"),
+		hookEntry("Write", "agentctl hook post-write"),
+	}
+	if !reflect.DeepEqual(hooks["PostToolUse"], wantPostToolUse) {
+		t.Errorf("PostToolUse = %v, want %v", hooks["PostToolUse"], wantPostToolUse)
+	}
+	wantStop := []interface{}{hookEntry("", "agentctl hook notify-stop")}
+	if !reflect.DeepEqual(hooks["Stop"], wantStop) {
+		t.Errorf("Stop = %v, want %v", hooks["Stop"], wantStop)
+	}
+
+	// Removing what the merge added restores the user's hooks
+	restored := Subtract(merged, Diff(merged, base))
+	if !reflect.DeepEqual(restored, base) {
+		t.Errorf("Subtract(Diff) = %v, want %v", restored, base)
+	}
+}
+
+func TestMergePermissions(t *testing.T) {
+	base := map[string]interface{}{
+		"permissions": map[string]interface{}{
+			"allow": []interface{}{"Bash(make:*)", "Bash(git:*)"},
+			"deny":  []interface{}{"Bash(docker:*)"},
+		},
+	}
+	template := map[string]interface{}{
+		"permissions": map[string]interface{}{
+			"allow": []interface{}{"Edit", "Bash(git:*)", "Bash(docker:*)"},
+			"deny":  []interface{}{"Read(.env)"},
+		},
+	}
+
+	merged := Merge(base, template)
+	permissions := merged["permissions"].(map[string]interface{})
+
+	wantAllow := []interface{}{"Bash(make:*)", "Bash(git:*)", "Edit"}
+	if !reflect.DeepEqual(permissions["allow"], wantAllow) {
+		t.Errorf("allow = %v, want %v (user order kept, denied rule skipped)", permissions["allow"], wantAllow)
+	}
+	wantDeny := []interface{}{"Bash(docker:*)", "Read(.env)"}
+	if !reflect.DeepEqual(permissions["deny"], wantDeny) {
+		t.Errorf("deny = %v, want %v", permissions["deny"], wantDeny)
+	}
+}
+
+func TestEffectiveDenyAcrossLayers(t *testing.T) {
+	tests := []struct {
+		name         string
+		user         map[string]interface{}
+		project      map[string]interface{}
+		wantAllow    []interface{}
+		wantAsk      []interface{}
+		wantDenyRule string
+	}{
+		{
+			name:         "user deny drops project allow",
+			user:         map[string]interface{}{"permissions": map[string]interface{}{"deny": []interface{}{"Bash(rm:*)"}}},
+			project:      map[string]interface{}{"permissions": map[string]interface{}{"allow": []interface{}{"Edit", "Bash(rm:*)"}}},
+			wantAllow:    []interface{}{"Edit"},
+			wantDenyRule: "Bash(rm:*)",
+		},
+		{
+			name:         "project deny drops user allow and ask",
+			user:         map[string]interface{}{"permissions": map[string]interface{}{"allow": []interface{}{"Bash(curl:*)", "Edit"}, "ask": []interface{}{"Bash(curl:*)"}}},
+			project:      map[string]interface{}{"permissions": map[string]interface{}{"deny": []interface{}{"Bash(curl:*)"}}},
+			wantAllow:    []interface{}{"Edit"},
+			wantAsk:      []interface{}{},
+			wantDenyRule: "Bash(curl:*)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			user := filepath.Join(dir, "user.json")
+			project := filepath.Join(dir, "project.json")
+			if err := SaveFile(user, tt.user); err != nil {
+				t.Fatal(err)
+			}
+			if err := SaveFile(project, tt.project); err != nil {
+				t.Fatal(err)
+			}
+
+			effective, err := Effective([]Layer{{Name: LayerUser, Path: user}, {Name: LayerProject, Path: project}})
+			if err != nil {
+				t.Fatalf("Effective failed: %v", err)
+			}
+			permissions := effective["permissions"].(map[string]interface{})
+			if !reflect.DeepEqual(permissions["allow"], tt.wantAllow) {
+				t.Errorf("allow = %v, want %v", permissions["allow"], tt.wantAllow)
+			}
+			if tt.wantAsk != nil && !reflect.DeepEqual(permissions["ask"], tt.wantAsk) {
+				t.Errorf("ask = %v, want %v", permissions["ask"], tt.wantAsk)
+			}
+			if !containsValue(permissions["deny"].([]interface{}), tt.wantDenyRule) {
+				t.Errorf("deny = %v, want it to contain %s", permissions["deny"], tt.wantDenyRule)
+			}
+		})
+	}
+}
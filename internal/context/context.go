@@ -5,6 +5,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+
+	"github.com/ryantking/agentctl/internal/fsutil"
 )
 
 // CopyClaudeContext copies Claude local settings and CLAUDE.md to a workspace.
@@ -35,7 +37,7 @@ func CopyClaudeContext(workspacePath, sourceRoot string) ([]string, error) {
 		}
 
 		// Create parent directory if needed
-		if err := os.MkdirAll(filepath.Dir(destFile), 0755); err != nil { //nolint:gosec // Context directories need to be readable
+		if err := fsutil.MkdirAll(filepath.Dir(destFile)); err != nil {
 			return nil, err
 		}
 
@@ -0,0 +1,135 @@
+// Package fsutil provides crash-safe file writes and a lock file that keeps
+// concurrent agentctl runs from interleaving writes in the same directory.
+package fsutil
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// LockFile is the name of the lock file created by Lock.
+const LockFile = ".agentctl.lock"
+
+// ErrLocked indicates another agentctl process holds the lock.
+var ErrLocked = errors.New("another agentctl process is running")
+
+// WriteFile writes data to a temporary file next to path and renames it into
+// place, so an interrupted run never leaves a partial file behind. An existing
+// file keeps its mode; a new file is created with perm, subject to the umask.
+// A symlinked path is written through to its target, so the link survives
+// (AGENTS.md is often a link to CLAUDE.md).
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	existing, statErr := os.Stat(path)
+
+	tmp, err := createTemp(path, perm)
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if statErr == nil {
+		if err := os.Chmod(tmpPath, existing.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// createTemp opens a new file beside path. Unlike os.CreateTemp, the file is
+// created with perm so the umask applies as it would for os.WriteFile.
+func createTemp(path string, perm os.FileMode) (*os.File, error) {
+	prefix := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp-")
+	for range 100 {
+		name := prefix + strconv.FormatUint(rand.Uint64(), 36)
+		f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, perm) //nolint:gosec // Path is derived from the destination file
+		if !errors.Is(err, fs.ErrExist) {
+			return f, err
+		}
+	}
+	return nil, fmt.Errorf("cannot create temporary file for %s", path)
+}
+
+// Lock creates LockFile in dir, failing with ErrLocked while another live
+// process holds it. Locks left by processes that have exited are taken over.
+// The returned function releases the lock.
+func Lock(dir string) (func(), error) {
+	path := filepath.Join(dir, LockFile)
+	for range 2 {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, FileMode()) //nolint:gosec // Path is the lock file in dir
+		if err == nil {
+			_, err = fmt.Fprintf(f, "%d\n", os.Getpid())
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				_ = os.Remove(path)
+				return nil, err
+			}
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+
+		pid, alive := holder(path)
+		if alive {
+			return nil, fmt.Errorf("%w (pid %d holds %s)", ErrLocked, pid, path)
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("%w (%s)", ErrLocked, path)
+}
+
+// LockWait is Lock for short critical sections: while another process holds
+// the lock it retries until timeout before giving up with ErrLocked.
+func LockWait(dir string, timeout time.Duration) (func(), error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		unlock, err := Lock(dir)
+		if !errors.Is(err, ErrLocked) || time.Now().After(deadline) {
+			return unlock, err
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// holder reports the pid recorded in the lock file at path and whether that
+// process is still running. Unreadable lock files count as held, since the
+// writer may not have recorded its pid yet.
+func holder(path string) (int, bool) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is the lock file
+	if err != nil {
+		return 0, !os.IsNotExist(err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, len(data) == 0
+	}
+	err = syscall.Kill(pid, 0)
+	return pid, err == nil || errors.Is(err, syscall.EPERM)
+}
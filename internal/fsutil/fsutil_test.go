@@ -0,0 +1,173 @@
+package fsutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "settings.json")
+
+	if err := WriteFile(path, []byte("one"), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.Chmod(path, 0640); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(path, []byte("two"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "two" {
+		t.Errorf("content = %q, want %q", data, "two")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Mode().Perm(); got != 0640 {
+		t.Errorf("mode = %v, want existing mode 0640", got)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the written file", len(entries))
+	}
+}
+
+func TestWriteFileSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "CLAUDE.md")
+	link := filepath.Join(dir, "AGENTS.md")
+	if err := os.WriteFile(target, []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("CLAUDE.md", link); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFile(link, []byte("new"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	info, err := os.Lstat(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("WriteFile() replaced the symlink with a regular file")
+	}
+	if data, _ := os.ReadFile(target); string(data) != "new" {
+		t.Errorf("link target content = %q, want %q", data, "new")
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("directory has %d entries, want the file and the link", len(entries))
+	}
+}
+
+func TestLock(t *testing.T) {
+	dir := t.TempDir()
+
+	unlock, err := Lock(dir)
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	if _, err := Lock(dir); !errors.Is(err, ErrLocked) {
+		t.Errorf("second Lock() error = %v, want ErrLocked", err)
+	}
+
+	unlock()
+	unlock, err = Lock(dir)
+	if err != nil {
+		t.Fatalf("Lock() after unlock error = %v", err)
+	}
+	unlock()
+	if _, err := os.Stat(filepath.Join(dir, LockFile)); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind after unlock")
+	}
+}
+
+func TestLockWait(t *testing.T) {
+	dir := t.TempDir()
+	unlock, err := Lock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LockWait(dir, 20*time.Millisecond); !errors.Is(err, ErrLocked) {
+		t.Errorf("LockWait() while held error = %v, want ErrLocked", err)
+	}
+
+	time.AfterFunc(20*time.Millisecond, unlock)
+	unlock, err = LockWait(dir, 5*time.Second)
+	if err != nil {
+		t.Fatalf("LockWait() after release error = %v", err)
+	}
+	unlock()
+}
+
+func TestLockStale(t *testing.T) {
+	dir := t.TempDir()
+	// Pid numbers this high are not handed out, so the holder is gone.
+	if err := os.WriteFile(filepath.Join(dir, LockFile), []byte("2147483646\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	unlock, err := Lock(dir)
+	if err != nil {
+		t.Fatalf("Lock() over stale lock error = %v", err)
+	}
+	unlock()
+}
+
+func TestParseFileMode(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    os.FileMode
+		wantErr bool
+	}{
+		{in: "0644", want: 0644},
+		{in: "600", want: 0600},
+		{in: "0640", want: 0640},
+		{in: "0755", wantErr: true},
+		{in: "0444", wantErr: true},
+		{in: "4644", wantErr: true},
+		{in: "rw-r--r--", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFileMode(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFileMode(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseFileMode(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDirMode(t *testing.T) {
+	defer SetFileMode(FileMode())
+
+	for file, dir := range map[os.FileMode]os.FileMode{0644: 0755, 0640: 0750, 0600: 0700} {
+		SetFileMode(file)
+		if got := DirMode(); got != dir {
+			t.Errorf("DirMode() with file mode %v = %v, want %v", file, got, dir)
+		}
+	}
+}
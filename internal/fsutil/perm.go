@@ -0,0 +1,58 @@
+package fsutil
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Permissions for files agentctl creates. The umask still applies, as it
+// does for os.WriteFile.
+const (
+	// DefaultFileMode is used for generated files unless --file-mode or
+	// AGENTCTL_FILE_MODE says otherwise.
+	DefaultFileMode os.FileMode = 0644
+	// PrivateFileMode is used for files that may hold credentials, such as
+	// settings.local.json, whatever the configured mode.
+	PrivateFileMode os.FileMode = 0600
+)
+
+// FileModeEnvVar is the environment variable that sets the file mode.
+const FileModeEnvVar = "AGENTCTL_FILE_MODE"
+
+var fileMode = DefaultFileMode
+
+// FileMode returns the mode for newly generated files.
+func FileMode() os.FileMode {
+	return fileMode
+}
+
+// DirMode returns the mode for newly created directories: the file mode
+// with search permission added wherever it grants read.
+func DirMode() os.FileMode {
+	return fileMode | (fileMode&0444)>>2
+}
+
+// SetFileMode sets the mode for newly generated files.
+func SetFileMode(mode os.FileMode) {
+	fileMode = mode
+}
+
+// ParseFileMode parses an octal file mode such as "0640". The mode must let
+// the owner read and write and may not set execute or special bits.
+func ParseFileMode(s string) (os.FileMode, error) {
+	n, err := strconv.ParseUint(s, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid file mode %q: must be octal, like 0640", s)
+	}
+	mode := os.FileMode(n)
+	if mode&^0666 != 0 || mode&0600 != 0600 {
+		return 0, fmt.Errorf("invalid file mode %q: must be within 0666 and include 0600", s)
+	}
+	return mode, nil
+}
+
+// MkdirAll creates path and any missing parents with DirMode.
+func MkdirAll(path string) error {
+	return os.MkdirAll(path, DirMode())
+}
@@ -0,0 +1,88 @@
+package git
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheTTL is how long read-only git results are reused within a process.
+// Commands like workspace list and status query the same metadata several
+// times per invocation; a short TTL collapses those into one subprocess
+// without risking stale results across separate agentctl runs.
+var CacheTTL = 2 * time.Second
+
+type cacheEntry struct {
+	output  string
+	expires time.Time
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = make(map[string]cacheEntry)
+)
+
+// runGitCached runs a read-only git command, reusing a recent successful
+// result for the same repository path and arguments. Failures are not cached,
+// so a transient error is retried on the next call. Output is returned
+// untrimmed.
+func runGitCached(repoPath string, args ...string) (string, error) {
+	key := repoPath + "\x00" + strings.Join(args, "\x00")
+
+	cacheMu.Lock()
+	entry, ok := cache[key]
+	cacheMu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.output, nil
+	}
+
+	output, err := runGitRaw(repoPath, args...)
+	if err != nil {
+		return output, err
+	}
+
+	cacheMu.Lock()
+	cache[key] = cacheEntry{output: output, expires: time.Now().Add(CacheTTL)}
+	cacheMu.Unlock()
+
+	return output, nil
+}
+
+// readOnlyCommands are git subcommands that never change repository state.
+// RunGit invalidates the cache after anything else.
+var readOnlyCommands = map[string]bool{
+	"cat-file":     true,
+	"check-ignore": true,
+	"cherry":       true,
+	"diff":         true,
+	"for-each-ref": true,
+	"log":          true,
+	"ls-files":     true,
+	"ls-tree":      true,
+	"merge-base":   true,
+	"rev-list":     true,
+	"rev-parse":    true,
+	"show":         true,
+	"show-ref":     true,
+	"status":       true,
+}
+
+// changesState reports whether a git command may modify the repository.
+// Unknown commands are assumed to, so the cache errs toward being dropped.
+func changesState(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	if args[0] == "worktree" {
+		return len(args) < 2 || args[1] != "list"
+	}
+	return !readOnlyCommands[args[0]]
+}
+
+// InvalidateCache drops all cached git results.
+// RunGit calls it after every command that may change state.
+func InvalidateCache() {
+	cacheMu.Lock()
+	cache = make(map[string]cacheEntry)
+	cacheMu.Unlock()
+}
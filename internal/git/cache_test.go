@@ -0,0 +1,76 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunGitCachedDoesNotCacheFailures(t *testing.T) {
+	repo := initTestRepo(t)
+
+	if _, err := runGitCached(repo, "rev-parse", "--verify", "refs/heads/later"); err == nil {
+		t.Fatal("rev-parse of a missing branch succeeded")
+	}
+	// Create the branch behind RunGit's back so nothing invalidates the cache
+	if out, err := exec.Command("git", "-C", repo, "branch", "later").CombinedOutput(); err != nil {
+		t.Fatalf("git branch: %v\n%s", err, out)
+	}
+	if _, err := runGitCached(repo, "rev-parse", "--verify", "refs/heads/later"); err != nil {
+		t.Errorf("rev-parse after creating the branch: %v", err)
+	}
+}
+
+func TestCommitInvalidatesCache(t *testing.T) {
+	repo := initTestRepo(t)
+
+	before, err := ListBranchHeads(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "app/main.go"), []byte("changed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if clean, _ := IsWorktreeClean(repo); clean {
+		t.Fatal("worktree reported clean after an edit")
+	}
+	if err := StageTracked(repo); err != nil {
+		t.Fatal(err)
+	}
+	if err := Commit(repo, "change"); err != nil {
+		t.Fatal(err)
+	}
+
+	if clean, status := IsWorktreeClean(repo); !clean {
+		t.Errorf("worktree still dirty after commit: %s", status)
+	}
+	after, err := ListBranchHeads(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after["main"].Commit == before["main"].Commit {
+		t.Errorf("main still at %s after commit", before["main"].Commit)
+	}
+}
+
+func TestChangesState(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"status", "--porcelain"}, false},
+		{[]string{"rev-parse", "HEAD"}, false},
+		{[]string{"worktree", "list", "--porcelain"}, false},
+		{[]string{"worktree", "add", "/tmp/x"}, true},
+		{[]string{"commit", "-m", "x"}, true},
+		{[]string{"fetch", "origin"}, true},
+		{[]string{"sparse-checkout", "set"}, true},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := changesState(tt.args); got != tt.want {
+			t.Errorf("changesState(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
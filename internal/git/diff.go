@@ -0,0 +1,125 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/fsutil"
+	"github.com/ryantking/agentctl/internal/trace"
+)
+
+// DiffText returns a unified diff from oldText to newText, labelled with name.
+// It uses git diff --no-index, so no repository is required. An empty string
+// means the texts are identical.
+func DiffText(name, oldText, newText string) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "agentctl-diff-")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	base := filepath.Base(name)
+	for dir, text := range map[string]string{"old": oldText, "new": newText} {
+		if err := fsutil.MkdirAll(filepath.Join(tmpDir, dir)); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(filepath.Join(tmpDir, dir, base), []byte(text), 0600); err != nil {
+			return "", err
+		}
+	}
+
+	span := trace.StartSpan(trace.KindGit, "git diff --no-index "+base)
+	// #nosec G204 -- paths are inside a temporary directory we created
+	cmd := exec.Command("git", "diff", "--no-index", "--no-color", "--",
+		filepath.Join("old", base), filepath.Join("new", base))
+	cmd.Dir = tmpDir
+	output, err := cmd.Output()
+
+	// Exit status 1 means the files differ
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		err = nil
+	}
+	span.End(err)
+	if err != nil {
+		return "", err
+	}
+
+	diff := string(output)
+	diff = strings.ReplaceAll(diff, "a/old/"+base, "a/"+name)
+	diff = strings.ReplaceAll(diff, "b/new/"+base, "b/"+name)
+	return diff, nil
+}
+
+// MergeText performs a three-way merge of the changes from base to current and
+// from base to other using git merge-file. It returns the merged text and the
+// number of conflicts, which are marked in the text with labels current and
+// other.
+func MergeText(current, base, other string, labels [2]string) (string, int, error) {
+	tmpDir, err := os.MkdirTemp("", "agentctl-merge-")
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	paths := make([]string, 3)
+	for i, text := range []string{current, base, other} {
+		paths[i] = filepath.Join(tmpDir, []string{"current", "base", "other"}[i])
+		if err := os.WriteFile(paths[i], []byte(text), 0600); err != nil {
+			return "", 0, err
+		}
+	}
+
+	span := trace.StartSpan(trace.KindGit, "git merge-file")
+	// #nosec G204 -- paths are inside a temporary directory we created
+	cmd := exec.Command("git", "merge-file", "-p",
+		"-L", labels[0], "-L", "original", "-L", labels[1],
+		paths[0], paths[1], paths[2])
+	output, err := cmd.Output()
+
+	// A positive exit status is the number of conflicts
+	conflicts := 0
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+		conflicts = exitErr.ExitCode()
+		err = nil
+	}
+	span.End(err)
+	if err != nil {
+		return "", 0, err
+	}
+	return string(output), conflicts, nil
+}
+
+// StagedDiff returns the diff of changes staged for commit in repoRoot.
+// An empty string means nothing is staged.
+func StagedDiff(repoRoot string) (string, error) {
+	return RunGit(repoRoot, "diff", "--cached", "--no-color", "--no-ext-diff")
+}
+
+// StageTracked stages modifications and deletions of tracked files, like
+// git commit --all.
+func StageTracked(repoRoot string) error {
+	_, err := RunGit(repoRoot, "add", "--update")
+	return err
+}
+
+// Commit creates a commit of the staged changes with message.
+func Commit(repoRoot, message string) error {
+	_, err := RunGit(repoRoot, "commit", "--quiet", "--message", message)
+	return err
+}
+
+// DiffFromMergeBase returns the changes in repoRoot's working tree, committed
+// or not, since HEAD diverged from base.
+func DiffFromMergeBase(repoRoot, base string) (string, error) {
+	mergeBase, err := RunGit(repoRoot, "merge-base", base, "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("cannot find merge base with %s: %w", base, err)
+	}
+	return RunGit(repoRoot, "diff", "--no-color", "--no-ext-diff", mergeBase)
+}
@@ -2,14 +2,36 @@ package git
 
 import (
 	"fmt"
+	"log/slog"
 	"os/exec"
 	"strings"
+
+	"github.com/ryantking/agentctl/internal/trace"
 )
 
 // RunGit executes a git command in the specified repository path.
 // Uses git -C flag to change directory before running the command.
 // Returns the stdout output as a string, or an error if the command fails.
+// Cached results are dropped after commands that may change state.
 func RunGit(repoPath string, args ...string) (string, error) {
+	if changesState(args) {
+		// Invalidate even on failure; a failed command may have changed state
+		defer InvalidateCache()
+	}
+	output, err := runGitRaw(repoPath, args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// runGitRaw executes a git command and returns its untrimmed stdout.
+// Needed for formats where leading whitespace is significant (e.g. porcelain status).
+func runGitRaw(repoPath string, args ...string) (result string, err error) {
+	span := trace.StartSpan(trace.KindGit, "git "+strings.Join(args, " "))
+	defer func() { span.End(err) }()
+	slog.Debug("git", "dir", repoPath, "args", args)
+
 	// #nosec G204 -- repoPath and args are validated by callers and come from trusted sources
 	cmd := exec.Command("git", append([]string{"-C", repoPath}, args...)...)
 	output, err := cmd.Output()
@@ -20,7 +42,7 @@ func RunGit(repoPath string, args ...string) (string, error) {
 		}
 		return "", fmt.Errorf("git command failed: %w", err)
 	}
-	return strings.TrimSpace(string(output)), nil
+	return string(output), nil
 }
 
 // RunGitLines executes a git command and returns the output as a slice of lines.
@@ -4,6 +4,7 @@ package git
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -41,10 +42,11 @@ func GetRepoName() (string, error) {
 // Returns empty string if in detached HEAD state.
 // Correctly handles worktrees by using git rev-parse --abbrev-ref HEAD.
 func GetCurrentBranch(path string) (string, error) {
-	branch, err := RunGit(path, "rev-parse", "--abbrev-ref", "HEAD")
+	output, err := runGitCached(path, "rev-parse", "--abbrev-ref", "HEAD")
 	if err != nil {
 		return "", err
 	}
+	branch := strings.TrimSpace(output)
 	// If detached HEAD, git returns "HEAD", treat as empty
 	if branch == "HEAD" {
 		return "", nil
@@ -68,3 +70,91 @@ func BranchExists(repoRoot, branchName string) (bool, error) {
 	// If output contains the branch name, it exists
 	return strings.Contains(output, fmt.Sprintf("refs/heads/%s", branchName)), nil
 }
+
+// AheadBehind returns how many commits local has that remote lacks (ahead)
+// and how many remote has that local lacks (behind), using a single
+// rev-list invocation.
+func AheadBehind(repoPath, local, remote string) (int, int, error) {
+	output, err := runGitCached(repoPath, "rev-list", "--left-right", "--count", fmt.Sprintf("%s...%s", local, remote))
+	if err != nil {
+		return 0, 0, err
+	}
+	fields := strings.Fields(output)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", output)
+	}
+	ahead, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	behind, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	return ahead, behind, nil
+}
+
+// UnmergedCommits returns how many commits on branch are not in base.
+// Commits that were rebased or cherry-picked onto base count as merged, since
+// git cherry compares patches rather than commit IDs.
+func UnmergedCommits(repoRoot, branch, base string) (int, error) {
+	if _, err := RunGit(repoRoot, "merge-base", "--is-ancestor", branch, base); err == nil {
+		return 0, nil
+	}
+	lines, err := RunGitLines(repoRoot, "cherry", base, branch)
+	if err != nil {
+		return 0, fmt.Errorf("cannot compare %s with %s: %w", branch, base, err)
+	}
+	unmerged := 0
+	for _, line := range lines {
+		if strings.HasPrefix(line, "+") {
+			unmerged++
+		}
+	}
+	return unmerged, nil
+}
+
+// BranchHead describes a local branch as reported by for-each-ref.
+type BranchHead struct {
+	Name     string
+	Commit   string
+	Upstream string
+	// UpstreamGone is set when the upstream branch was deleted on the remote
+	// (and pruned locally).
+	UpstreamGone bool
+}
+
+// ListBranchHeads returns every local branch with its commit and upstream
+// in a single for-each-ref call, keyed by branch name.
+func ListBranchHeads(repoRoot string) (map[string]BranchHead, error) {
+	output, err := runGitCached(repoRoot, "for-each-ref", "--format=%(refname:short)%00%(objectname)%00%(upstream:short)%00%(upstream:track)", "refs/heads/")
+	if err != nil {
+		return nil, err
+	}
+	heads := make(map[string]BranchHead)
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 4 || fields[0] == "" {
+			continue
+		}
+		heads[fields[0]] = BranchHead{
+			Name:         fields[0],
+			Commit:       fields[1],
+			Upstream:     fields[2],
+			UpstreamGone: fields[3] == "[gone]",
+		}
+	}
+	return heads, nil
+}
+
+// Fetch updates remote-tracking branches from origin, pruning branches that
+// were deleted there. It returns ErrNoRemote when origin is not configured.
+func Fetch(repoRoot string) error {
+	if _, err := RunGit(repoRoot, "remote", "get-url", "origin"); err != nil {
+		return ErrNoRemote
+	}
+	if _, err := RunGit(repoRoot, "fetch", "--prune", "--no-tags", "--quiet", "origin"); err != nil {
+		return fmt.Errorf("failed to fetch origin: %w", err)
+	}
+	return nil
+}
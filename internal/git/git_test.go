@@ -77,3 +77,40 @@ func TestIsWorktreeClean(t *testing.T) {
 		t.Error("IsWorktreeClean returned empty status")
 	}
 }
+
+func TestParsePorcelainZ(t *testing.T) {
+	output := " M modified.go\x00M  staged.go\x00MM both.go\x00?? new.go\x00R  renamed.go\x00old.go\x00"
+
+	staged, modified, untracked := parsePorcelainZ(output)
+	if staged != 3 {
+		t.Errorf("Expected 3 staged, got %d", staged)
+	}
+	if modified != 2 {
+		t.Errorf("Expected 2 modified, got %d", modified)
+	}
+	if untracked != 1 {
+		t.Errorf("Expected 1 untracked, got %d", untracked)
+	}
+}
+
+func TestMergeText(t *testing.T) {
+	base := "one\ntwo\nthree\n"
+	current := "one\ntwo (edited)\nthree\n"
+	other := "one\ntwo\nthree\nfour\n"
+
+	merged, conflicts, err := MergeText(current, base, other, [2]string{"current", "new"})
+	if err != nil {
+		t.Fatalf("MergeText() error = %v", err)
+	}
+	if conflicts != 0 || merged != "one\ntwo (edited)\nthree\nfour\n" {
+		t.Errorf("MergeText() = %q, %d conflicts; want clean merge", merged, conflicts)
+	}
+
+	_, conflicts, err = MergeText("one\nA\nthree\n", base, "one\nB\nthree\n", [2]string{"current", "new"})
+	if err != nil {
+		t.Fatalf("MergeText() error = %v", err)
+	}
+	if conflicts != 1 {
+		t.Errorf("MergeText() conflicts = %d, want 1", conflicts)
+	}
+}
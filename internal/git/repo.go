@@ -6,3 +6,6 @@ import (
 
 // ErrNotInGitRepo is returned when a git repository cannot be found.
 var ErrNotInGitRepo = fmt.Errorf("not in a git repository")
+
+// ErrNoRemote is returned when a repository has no origin remote.
+var ErrNoRemote = fmt.Errorf("no origin remote configured")
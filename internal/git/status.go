@@ -9,46 +9,12 @@ import (
 // IsWorktreeClean checks if a worktree has uncommitted changes.
 // Returns (isClean, statusMessage).
 func IsWorktreeClean(worktreePath string) (bool, string) {
-	lines, err := RunGitLines(worktreePath, "status", "--porcelain")
+	output, err := runGitCached(worktreePath, "status", "--porcelain", "-z")
 	if err != nil {
 		return false, fmt.Sprintf("failed to check status: %v", err)
 	}
 
-	if len(lines) == 0 {
-		return true, "clean"
-	}
-
-	// Parse porcelain output
-	// Format: XY filename
-	// X = status of index, Y = status of work tree
-	// Common values: M = modified, A = added, D = deleted, ? = untracked, space = unmodified
-	var staged, modified, untracked int
-	for _, line := range lines {
-		if len(line) < 3 {
-			continue
-		}
-		x := line[0]
-		y := line[1]
-
-		// Count staged changes (X != space)
-		if x != ' ' && x != '?' {
-			staged++
-		}
-
-		// Count modified/untracked in worktree (Y != space)
-		if y != ' ' {
-			if y == '?' {
-				untracked++
-			} else {
-				modified++
-			}
-		}
-
-		// Also count untracked files (X == '?' or Y == '?')
-		if x == '?' {
-			untracked++
-		}
-	}
+	staged, modified, untracked := parsePorcelainZ(output)
 
 	var parts []string
 	if staged > 0 {
@@ -68,6 +34,45 @@ func IsWorktreeClean(worktreePath string) (bool, string) {
 	return false, strings.Join(parts, ", ")
 }
 
+// parsePorcelainZ counts staged, modified, and untracked entries in
+// `git status --porcelain -z` output.
+// Format: XY<space>path, NUL-terminated; renames and copies are followed by
+// an extra NUL-terminated entry holding the original path.
+// X = status of index, Y = status of work tree
+// Common values: M = modified, A = added, D = deleted, ? = untracked, space = unmodified
+func parsePorcelainZ(output string) (staged, modified, untracked int) {
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		x := entry[0]
+		y := entry[1]
+
+		if x == '?' {
+			untracked++
+			continue
+		}
+
+		// Count staged changes (X != space)
+		if x != ' ' {
+			staged++
+		}
+
+		// Count modified in worktree (Y != space)
+		if y != ' ' {
+			modified++
+		}
+
+		// Skip the original path that follows a rename or copy
+		if x == 'R' || x == 'C' {
+			i++
+		}
+	}
+	return staged, modified, untracked
+}
+
 // GetStatusSummary returns a brief git status summary.
 func GetStatusSummary(repoRoot string) (string, error) {
 	isClean, status := IsWorktreeClean(repoRoot)
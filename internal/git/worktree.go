@@ -2,7 +2,6 @@ package git
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 )
@@ -12,136 +11,141 @@ type Worktree struct {
 	Path   string
 	Branch string
 	Commit string
+	// Bare is set for the entry of a bare repository, which has no checkout.
+	Bare bool
+	// Locked is set when the worktree is locked against pruning; LockReason
+	// holds the reason given to git worktree lock, if any.
+	Locked     bool
+	LockReason string
+	// Prunable is set when git considers the worktree stale (for example its
+	// directory was deleted); PruneReason explains why.
+	Prunable    bool
+	PruneReason string
 }
 
-// ListWorktrees returns all worktrees for a repository.
+// ListWorktrees returns all worktrees for a repository, the main worktree first.
 func ListWorktrees(repoRoot string) ([]Worktree, error) {
+	output, err := runGitRaw(repoRoot, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	return parseWorktreeList(output), nil
+}
+
+// parseWorktreeList parses the output of git worktree list --porcelain:
+// blank-line separated records of "key [value]" lines.
+func parseWorktreeList(output string) []Worktree {
 	var worktrees []Worktree
+	var wt *Worktree
 
-	// Add the main worktree
-	mainWorktree, err := getMainWorktree(repoRoot)
-	if err == nil {
-		worktrees = append(worktrees, mainWorktree)
-	}
+	for _, line := range strings.Split(output, "\n") {
+		key, value, _ := strings.Cut(strings.TrimRight(line, "\r"), " ")
+		if key == "" {
+			wt = nil
+			continue
+		}
+		if key == "worktree" {
+			worktrees = append(worktrees, Worktree{Path: value})
+			wt = &worktrees[len(worktrees)-1]
+			continue
+		}
+		if wt == nil {
+			continue
+		}
 
-	// List additional worktrees from .git/worktrees
-	worktreesDir := filepath.Join(repoRoot, ".git", "worktrees")
-	if info, err := os.Stat(worktreesDir); err == nil && info.IsDir() {
-		entries, err := os.ReadDir(worktreesDir)
-		if err == nil {
-			for _, entry := range entries {
-				if !entry.IsDir() {
-					continue
-				}
-				wtPath := filepath.Join(worktreesDir, entry.Name())
-				wt, err := parseWorktreeDir(wtPath, repoRoot)
-				if err == nil {
-					worktrees = append(worktrees, wt)
-				}
+		switch key {
+		case "HEAD":
+			wt.Commit = value
+			if len(wt.Commit) > 8 {
+				wt.Commit = wt.Commit[:8]
 			}
+		case "branch":
+			wt.Branch = strings.TrimPrefix(value, "refs/heads/")
+		case "bare":
+			wt.Bare = true
+		case "locked":
+			wt.Locked = true
+			wt.LockReason = value
+		case "prunable":
+			wt.Prunable = true
+			wt.PruneReason = value
 		}
 	}
 
-	return worktrees, nil
+	return worktrees
 }
 
-// getMainWorktree gets information about the main worktree.
-func getMainWorktree(repoRoot string) (Worktree, error) {
-	wt := Worktree{Path: repoRoot}
-
-	// Get branch name
-	branch, err := GetCurrentBranch(repoRoot)
-	if err == nil && branch != "" {
-		wt.Branch = branch
-	}
-
-	// Get commit hash
-	commit, err := RunGit(repoRoot, "rev-parse", "--short", "HEAD")
-	if err == nil && commit != "" {
-		wt.Commit = commit
-	}
-
-	return wt, nil
+// AddWorktree creates a new worktree.
+// If createBranch is true, creates a new branch from baseBranch (or HEAD if baseBranch is empty).
+// If createBranch is false, checks out the existing branch.
+func AddWorktree(repoRoot, path, branch string, createBranch bool, baseBranch string) error {
+	return addWorktree(repoRoot, path, branch, createBranch, baseBranch, false)
 }
 
-// parseWorktreeDir parses a worktree directory in .git/worktrees.
-func parseWorktreeDir(worktreeDir, repoRoot string) (Worktree, error) {
-	wt := Worktree{}
+// AddSparseWorktree creates a new worktree limited to the given directories.
+// The worktree is created without a checkout, configured with cone-mode
+// sparse-checkout (which always includes files at the repository root),
+// and then populated, so only the listed paths are ever written to disk.
+func AddSparseWorktree(repoRoot, path, branch string, createBranch bool, baseBranch string, sparsePaths []string) error {
+	if err := addWorktree(repoRoot, path, branch, createBranch, baseBranch, true); err != nil {
+		return err
+	}
 
-	// Read gitdir file to get the worktree .git path
-	gitdirFile := filepath.Join(worktreeDir, "gitdir")
-	data, err := os.ReadFile(gitdirFile) //nolint:gosec // Reading gitdir file is safe, path is controlled
+	absPath, err := filepath.Abs(path)
 	if err != nil {
-		return wt, err
+		return fmt.Errorf("failed to resolve path: %w", err)
 	}
-	gitdirPath := strings.TrimSpace(string(data))
 
-	// The gitdir file typically contains the path to the worktree directory.
-	// Some git versions may include a trailing .git, so handle both cases.
-	if strings.HasSuffix(gitdirPath, ".git") {
-		wt.Path = filepath.Dir(gitdirPath)
-	} else {
-		wt.Path = gitdirPath
+	args := append([]string{"sparse-checkout", "set", "--cone"}, sparsePaths...)
+	if _, err := RunGit(absPath, args...); err != nil {
+		discardWorktree(repoRoot, absPath, branch, createBranch)
+		return fmt.Errorf("failed to configure sparse-checkout: %w", err)
 	}
 
-	// Read HEAD file to get commit/branch
-	headFile := filepath.Join(worktreeDir, "HEAD")
-	data, err = os.ReadFile(headFile) //nolint:gosec // Reading HEAD file is safe, path is controlled
-	if err != nil {
-		return wt, err
-	}
-	headRef := strings.TrimSpace(string(data))
-
-	if strings.HasPrefix(headRef, "ref: refs/heads/") {
-		wt.Branch = strings.TrimPrefix(headRef, "ref: refs/heads/")
-		// Get commit from branch ref in main repo
-		refPath := filepath.Join(repoRoot, ".git", headRef[5:]) // Skip "ref: "
-		if data, err := os.ReadFile(refPath); err == nil { //nolint:gosec // Reading ref file is safe, path is controlled
-			commit := strings.TrimSpace(string(data))
-			if len(commit) > 8 {
-				wt.Commit = commit[:8]
-			} else {
-				wt.Commit = commit
-			}
-		}
-	} else {
-		// Detached HEAD - commit hash is in the HEAD file
-		commit := strings.TrimSpace(string(data))
-		if len(commit) > 8 {
-			wt.Commit = commit[:8]
-		} else {
-			wt.Commit = commit
-		}
+	// Populate the index and working tree from HEAD, honoring the sparse patterns
+	if _, err := RunGit(absPath, "read-tree", "-mu", "HEAD"); err != nil {
+		discardWorktree(repoRoot, absPath, branch, createBranch)
+		return fmt.Errorf("failed to check out sparse worktree: %w", err)
 	}
 
-	return wt, nil
+	return nil
+}
+
+// discardWorktree undoes a partially set up worktree so the same branch can
+// be retried: it removes the worktree and deletes the branch if it was
+// created for it. Cleanup is best effort; the original error is what matters.
+func discardWorktree(repoRoot, absPath, branch string, createdBranch bool) {
+	_, _ = RunGit(repoRoot, "worktree", "remove", "--force", absPath)
+	if createdBranch {
+		_, _ = RunGit(repoRoot, "branch", "-D", branch)
+	}
 }
 
-// AddWorktree creates a new worktree.
-// If createBranch is true, creates a new branch from baseBranch (or HEAD if baseBranch is empty).
-// If createBranch is false, checks out the existing branch.
-func AddWorktree(repoRoot, path, branch string, createBranch bool, baseBranch string) error {
+func addWorktree(repoRoot, path, branch string, createBranch bool, baseBranch string, noCheckout bool) error {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		return fmt.Errorf("failed to resolve path: %w", err)
 	}
 
+	args := []string{"worktree", "add"}
+	if noCheckout {
+		args = append(args, "--no-checkout")
+	}
+
 	if createBranch {
 		// Create new branch from base
-		if baseBranch == "" {
-			// Use HEAD as base
-			_, err = RunGit(repoRoot, "worktree", "add", "-b", branch, absPath)
-		} else {
-			// Use specified base branch
-			_, err = RunGit(repoRoot, "worktree", "add", "-b", branch, absPath, baseBranch)
+		args = append(args, "-b", branch, absPath)
+		if baseBranch != "" {
+			// Use specified base branch, otherwise HEAD
+			args = append(args, baseBranch)
 		}
-		if err != nil {
+		if _, err = RunGit(repoRoot, args...); err != nil {
 			return fmt.Errorf("failed to create worktree with new branch: %w", err)
 		}
 	} else {
 		// Checkout existing branch
-		_, err = RunGit(repoRoot, "worktree", "add", absPath, branch)
-		if err != nil {
+		args = append(args, absPath, branch)
+		if _, err = RunGit(repoRoot, args...); err != nil {
 			return fmt.Errorf("failed to create worktree: %w", err)
 		}
 	}
@@ -0,0 +1,142 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseWorktreeList(t *testing.T) {
+	output := `worktree /repo
+HEAD 1234567890abcdef1234567890abcdef12345678
+branch refs/heads/main
+
+worktree /home/me/.claude/workspaces/repo/feature
+HEAD abcdef1234567890abcdef1234567890abcdef12
+branch refs/heads/feature/x
+locked on a USB drive
+
+worktree /tmp/gone
+HEAD 0000000011111111222222223333333344444444
+detached
+prunable gitdir file points to non-existent location
+
+`
+	got := parseWorktreeList(output)
+	want := []Worktree{
+		{Path: "/repo", Branch: "main", Commit: "12345678"},
+		{Path: "/home/me/.claude/workspaces/repo/feature", Branch: "feature/x", Commit: "abcdef12", Locked: true, LockReason: "on a USB drive"},
+		{Path: "/tmp/gone", Commit: "00000000", Prunable: true, PruneReason: "gitdir file points to non-existent location"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseWorktreeList() returned %d worktrees, want %d: %+v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("worktree %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseWorktreeListBare(t *testing.T) {
+	got := parseWorktreeList("worktree /srv/repo.git\nbare\n\nworktree /srv/wt\nHEAD abc\nbranch refs/heads/dev\n")
+	if len(got) != 2 || !got[0].Bare || got[1].Branch != "dev" || got[1].Commit != "abc" {
+		t.Errorf("parseWorktreeList() = %+v", got)
+	}
+}
+
+// initTestRepo creates a repository with one commit containing app/main.go
+// and docs/readme.md.
+func initTestRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	InvalidateCache()
+
+	repo := filepath.Join(t.TempDir(), "repo")
+	for _, file := range []string{"app/main.go", "docs/readme.md"} {
+		path := filepath.Join(repo, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(file+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main", repo},
+		{"-C", repo, "add", "."},
+		{"-C", repo, "commit", "-q", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	return repo
+}
+
+func TestAddSparseWorktree(t *testing.T) {
+	repo := initTestRepo(t)
+	path := filepath.Join(t.TempDir(), "sparse")
+
+	if err := AddSparseWorktree(repo, path, "feature", true, "main", []string{"app"}); err != nil {
+		t.Fatalf("AddSparseWorktree() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(path, "app", "main.go")); err != nil {
+		t.Errorf("sparse path not checked out: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(path, "docs")); !os.IsNotExist(err) {
+		t.Errorf("path outside the sparse set was checked out")
+	}
+	if clean, status := IsWorktreeClean(path); !clean {
+		t.Errorf("sparse worktree is not clean: %s", status)
+	}
+}
+
+func TestAddSparseWorktreeFailureCleansUp(t *testing.T) {
+	repo := initTestRepo(t)
+	path := filepath.Join(t.TempDir(), "sparse")
+
+	// Cone mode rejects paths outside the repository
+	if err := AddSparseWorktree(repo, path, "feature", true, "main", []string{"../outside"}); err == nil {
+		t.Fatal("AddSparseWorktree() succeeded with an invalid sparse path")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("failed worktree directory left behind")
+	}
+	if exists, err := BranchExists(repo, "feature"); err != nil || exists {
+		t.Errorf("BranchExists() = %v, %v; want the new branch deleted", exists, err)
+	}
+	worktrees, err := ListWorktrees(repo)
+	if err != nil || len(worktrees) != 1 {
+		t.Errorf("ListWorktrees() = %+v, %v; want only the main checkout", worktrees, err)
+	}
+
+	// The same branch can be retried once the paths are fixed
+	if err := AddSparseWorktree(repo, path, "feature", true, "main", []string{"docs"}); err != nil {
+		t.Fatalf("retry AddSparseWorktree() error = %v", err)
+	}
+}
+
+func TestAddSparseWorktreeFailureKeepsExistingBranch(t *testing.T) {
+	repo := initTestRepo(t)
+	if out, err := exec.Command("git", "-C", repo, "branch", "existing").CombinedOutput(); err != nil {
+		t.Fatalf("git branch: %v\n%s", err, out)
+	}
+	InvalidateCache()
+
+	path := filepath.Join(t.TempDir(), "sparse")
+	if err := AddSparseWorktree(repo, path, "existing", false, "", []string{"../outside"}); err == nil {
+		t.Fatal("AddSparseWorktree() succeeded with an invalid sparse path")
+	}
+	if exists, err := BranchExists(repo, "existing"); err != nil || !exists {
+		t.Errorf("BranchExists() = %v, %v; want the pre-existing branch kept", exists, err)
+	}
+}
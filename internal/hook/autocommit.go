@@ -3,128 +3,118 @@ package hook
 
 import (
 	"fmt"
+	"log/slog"
 	"path/filepath"
 
 	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/workspace"
 )
 
 // PostEdit auto-commits changes if on a feature branch.
 // Reads file path from stdin JSON.
 func PostEdit(filePath string) error {
-	if filePath == "" {
-		return nil
-	}
+	return autoCommit(filePath, false)
+}
 
-	repoRoot, err := git.GetRepoRoot()
+// PostWrite auto-commits new files if on a feature branch.
+// Reads file path from stdin JSON.
+func PostWrite(filePath string) error {
+	return autoCommit(filePath, true)
+}
+
+func autoCommit(filePath string, newFile bool) error {
+	plan, reason, err := planAutoCommit(filePath, newFile)
 	if err != nil {
-		return nil // Not in a repo, skip
+		return err
 	}
-
-	branch, err := git.GetCurrentBranch(repoRoot)
-	if err != nil || branch == "" {
+	if plan == nil {
+		slog.Debug("auto-commit skipped", "file", filePath, "reason", reason)
 		return nil
 	}
+	return plan.execute()
+}
 
-	if isMainBranch(branch) {
-		return nil // Skip on main/master
-	}
-
-	return gitAddAndCommit(repoRoot, filePath)
+// autoCommitPlan describes the commit an auto-commit hook would create.
+type autoCommitPlan struct {
+	repoRoot string
+	relPath  string
+	message  string
 }
 
-// PostWrite auto-commits new files if on a feature branch.
-// Reads file path from stdin JSON.
-func PostWrite(filePath string) error {
+// planAutoCommit decides whether filePath should be auto-committed.
+// Returns a nil plan and the reason when the hook should do nothing.
+func planAutoCommit(filePath string, newFile bool) (*autoCommitPlan, string, error) {
 	if filePath == "" {
-		return nil
+		return nil, "no file path in hook input", nil
 	}
 
 	repoRoot, err := git.GetRepoRoot()
 	if err != nil {
-		return nil // Not in a repo, skip
+		return nil, "not in a git repository", nil // Not in a repo, skip
 	}
 
 	branch, err := git.GetCurrentBranch(repoRoot)
 	if err != nil || branch == "" {
-		return nil
+		return nil, "detached HEAD", nil
 	}
 
 	if isMainBranch(branch) {
-		return nil // Skip on main/master
+		return nil, fmt.Sprintf("on %s; auto-commit only runs on feature branches", branch), nil // Skip on main/master
 	}
 
-	return gitAddAndCommitNewFile(repoRoot, filePath)
-}
-
-func isMainBranch(branch string) bool {
-	return branch == "main" || branch == "master"
-}
+	if cfg, err := workspace.LoadConfig(repoRoot); err == nil && !cfg.AutoCommitEnabled() {
+		return nil, "auto-commit is disabled for this workspace (hooks.autocommit=false)", nil
+	}
 
-func gitAddAndCommit(repoRoot, filePath string) error {
 	// Make path relative to repo root
 	absPath, err := filepath.Abs(filePath)
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 	relPath, err := filepath.Rel(repoRoot, absPath)
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 
-	// Stage the file
-	if _, err := git.RunGit(repoRoot, "add", relPath); err != nil {
-		return fmt.Errorf("failed to stage file: %w", err)
-	}
-
-	// Check if there are staged changes
-	_, err = git.RunGit(repoRoot, "diff", "--cached", "--quiet", relPath)
-	if err == nil {
-		// No changes to commit (exit code 0 means no diff)
-		return nil
-	}
-
-	// Calculate commit message
 	filename := filepath.Base(filePath)
 	msg := fmt.Sprintf("Update %s: moderate changes", filename)
-
-	// Create commit
-	if _, err := git.RunGit(repoRoot, "commit", "-m", msg); err != nil {
-		return fmt.Errorf("failed to create commit: %w", err)
+	if newFile {
+		msg = fmt.Sprintf("Add new file: %s", filename)
 	}
 
-	return nil
+	return &autoCommitPlan{repoRoot: repoRoot, relPath: relPath, message: msg}, "", nil
 }
 
-func gitAddAndCommitNewFile(repoRoot, filePath string) error {
-	// Make path relative to repo root
-	absPath, err := filepath.Abs(filePath)
-	if err != nil {
-		return err
-	}
-	relPath, err := filepath.Rel(repoRoot, absPath)
-	if err != nil {
-		return err
-	}
+func isMainBranch(branch string) bool {
+	return branch == "main" || branch == "master"
+}
 
+// execute stages the file and commits it if it has changes.
+func (p *autoCommitPlan) execute() error {
 	// Stage the file
-	if _, err := git.RunGit(repoRoot, "add", relPath); err != nil {
+	if _, err := git.RunGit(p.repoRoot, "add", p.relPath); err != nil {
 		return fmt.Errorf("failed to stage file: %w", err)
 	}
 
-	// Check if file is staged
-	_, err = git.RunGit(repoRoot, "diff", "--cached", "--quiet", relPath)
+	// Check if there are staged changes
+	_, err := git.RunGit(p.repoRoot, "diff", "--cached", "--quiet", p.relPath)
 	if err == nil {
 		// No changes to commit (exit code 0 means no diff)
+		slog.Debug("auto-commit skipped", "file", p.relPath, "reason", "no changes")
 		return nil
 	}
 
-	filename := filepath.Base(filePath)
-	msg := fmt.Sprintf("Add new file: %s", filename)
-
 	// Create commit
-	if _, err := git.RunGit(repoRoot, "commit", "-m", msg); err != nil {
+	if _, err := git.RunGit(p.repoRoot, "commit", "-m", p.message); err != nil {
 		return fmt.Errorf("failed to create commit: %w", err)
 	}
 
+	slog.Info("auto-committed", "file", p.relPath, "message", p.message)
 	return nil
 }
+
+// hasChanges reports whether the planned file differs from HEAD, without staging it.
+func (p *autoCommitPlan) hasChanges() bool {
+	status, err := git.RunGit(p.repoRoot, "status", "--porcelain", "--", p.relPath)
+	return err == nil && status != ""
+}
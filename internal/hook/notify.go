@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ryantking/agentctl/internal/notify"
+	"github.com/ryantking/agentctl/internal/workspace"
 )
 
 // detectAgent detects the agent type and returns (appName, sender).
@@ -52,34 +53,51 @@ func detectAgent() (string, string) {
 
 // NotifyInput sends notification when input is needed.
 func NotifyInput(message string) error {
+	if !notificationsEnabled() {
+		return nil
+	}
 	appName, sender := detectAgent()
 	return NotifyInputWithSender(message, appName, sender)
 }
 
 // NotifyInputWithSender sends notification with a custom sender.
 func NotifyInputWithSender(message string, appName, sender string) error {
+	return notify.Send(inputNotification(message, appName, sender))
+}
+
+// inputNotification builds the notification sent when input is needed.
+func inputNotification(message string, appName, sender string) notify.Options {
 	projectName := getProjectName()
 	if message == "" {
 		message = "Input needed to continue"
 	}
-	return notify.Send(notify.Options{
+	return notify.Options{
+		Event:    notify.EventInput,
 		Title:    appName,
 		Subtitle: projectName,
 		Message:  message,
 		Sound:    "",
 		Group:    fmt.Sprintf("agentctl-%s", projectName),
 		Sender:   sender,
-	})
+	}
 }
 
 // NotifyStop sends notification when a task completes.
 func NotifyStop(transcriptPath string) error {
+	if !notificationsEnabled() {
+		return nil
+	}
 	appName, sender := detectAgent()
 	return NotifyStopWithSender(transcriptPath, appName, sender)
 }
 
 // NotifyStopWithSender sends stop notification with a custom sender.
 func NotifyStopWithSender(transcriptPath string, appName, sender string) error {
+	return notify.Send(stopNotification(transcriptPath, appName, sender))
+}
+
+// stopNotification builds the notification sent when a task completes.
+func stopNotification(transcriptPath string, appName, sender string) notify.Options {
 	projectName := getProjectName()
 	timeStr := getTime()
 
@@ -90,36 +108,56 @@ func NotifyStopWithSender(transcriptPath string, appName, sender string) error {
 		}
 	}
 
-	return notify.Send(notify.Options{
+	return notify.Options{
+		Event:    notify.EventStop,
 		Title:    fmt.Sprintf("✅ %s", appName),
 		Subtitle: projectName,
 		Message:  message,
 		Sound:    "",
 		Group:    fmt.Sprintf("agentctl-%s", projectName),
 		Sender:   sender,
-	})
+	}
 }
 
 // NotifyError sends error notification.
 func NotifyError(message string) error {
+	if !notificationsEnabled() {
+		return nil
+	}
 	appName, sender := detectAgent()
 	return NotifyErrorWithSender(message, appName, sender)
 }
 
 // NotifyErrorWithSender sends error notification with a custom sender.
 func NotifyErrorWithSender(message string, appName, sender string) error {
+	return notify.Send(errorNotification(message, appName, sender))
+}
+
+// errorNotification builds the notification sent on errors.
+func errorNotification(message string, appName, sender string) notify.Options {
 	projectName := getProjectName()
 	if message == "" {
 		message = "An error occurred"
 	}
-	return notify.Send(notify.Options{
+	return notify.Options{
+		Event:    notify.EventError,
 		Title:    fmt.Sprintf("❌ %s", appName),
 		Subtitle: projectName,
 		Message:  message,
 		Sound:    "Basso",
 		Group:    fmt.Sprintf("agentctl-%s", projectName),
 		Sender:   sender,
-	})
+	}
+}
+
+// notificationsEnabled checks the current workspace's hook policy.
+// Notifications stay enabled when the policy cannot be read.
+func notificationsEnabled() bool {
+	cfg, err := workspace.LoadConfig(".")
+	if err != nil {
+		return true
+	}
+	return cfg.NotifyEnabled()
 }
 
 func getProjectName() string {
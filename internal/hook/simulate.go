@@ -0,0 +1,146 @@
+package hook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/notify"
+)
+
+// Hook event names as used in Claude Code settings.json.
+const (
+	EventSessionStart     = "SessionStart"
+	EventUserPromptSubmit = "UserPromptSubmit"
+	EventNotification     = "Notification"
+	EventStop             = "Stop"
+	EventPostToolUse      = "PostToolUse"
+)
+
+// Events lists the hook events that Simulate understands.
+var Events = []string{
+	EventSessionStart,
+	EventUserPromptSubmit,
+	EventNotification,
+	EventStop,
+	EventPostToolUse,
+}
+
+// Action describes one effect a hook handler would have.
+type Action struct {
+	Kind    string                 `json:"kind"` // commit, notification, context, or skip
+	Handler string                 `json:"handler"`
+	Summary string                 `json:"summary"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// Simulate works out what the agentctl hook handler for event would do with
+// the given input, without committing or sending notifications.
+func Simulate(event string, input *HookInput) ([]Action, error) {
+	if input == nil {
+		input = &HookInput{}
+	}
+
+	switch normalizeEvent(event) {
+	case EventSessionStart, EventUserPromptSubmit:
+		context, err := ContextInfo()
+		if err != nil {
+			return nil, err
+		}
+		return []Action{{
+			Kind:    "context",
+			Handler: "inject-context",
+			Summary: fmt.Sprintf("inject %d lines of context into the prompt", strings.Count(context, "\n")+1),
+			Details: map[string]interface{}{"output": context},
+		}}, nil
+
+	case EventNotification:
+		if !notificationsEnabled() {
+			return []Action{{Kind: "skip", Handler: "notify-input", Summary: "notifications are disabled for this workspace (hooks.notify=false)"}}, nil
+		}
+		appName, sender := detectAgent()
+		return []Action{notificationAction("notify-input", inputNotification(input.Message, appName, sender))}, nil
+
+	case EventStop:
+		if !notificationsEnabled() {
+			return []Action{{Kind: "skip", Handler: "notify-stop", Summary: "notifications are disabled for this workspace (hooks.notify=false)"}}, nil
+		}
+		appName, sender := detectAgent()
+		return []Action{notificationAction("notify-stop", stopNotification(input.TranscriptPath, appName, sender))}, nil
+
+	case EventPostToolUse:
+		return simulatePostToolUse(input)
+	}
+
+	return nil, fmt.Errorf("unknown hook event %q (expected one of: %s)", event, strings.Join(Events, ", "))
+}
+
+func simulatePostToolUse(input *HookInput) ([]Action, error) {
+	var handler string
+	var newFile bool
+	switch input.ToolName {
+	case "Edit", "MultiEdit":
+		handler = "post-edit"
+	case "Write":
+		handler, newFile = "post-write", true
+	case "":
+		return nil, fmt.Errorf("PostToolUse requires a tool name (tool_name in the payload or --tool)")
+	default:
+		return []Action{{Kind: "skip", Handler: "", Summary: fmt.Sprintf("no agentctl hook is configured for tool %s", input.ToolName)}}, nil
+	}
+
+	plan, reason, err := planAutoCommit(GetFilePath(input), newFile)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return []Action{{Kind: "skip", Handler: handler, Summary: reason}}, nil
+	}
+	if !plan.hasChanges() {
+		return []Action{{Kind: "skip", Handler: handler, Summary: fmt.Sprintf("%s has no changes to commit", plan.relPath)}}, nil
+	}
+
+	return []Action{{
+		Kind:    "commit",
+		Handler: handler,
+		Summary: fmt.Sprintf("commit %s: %q", plan.relPath, plan.message),
+		Details: map[string]interface{}{
+			"repo":    plan.repoRoot,
+			"file":    plan.relPath,
+			"message": plan.message,
+		},
+	}}, nil
+}
+
+func notificationAction(handler string, opts notify.Options) Action {
+	details := map[string]interface{}{
+		"title":    opts.Title,
+		"subtitle": opts.Subtitle,
+		"message":  opts.Message,
+		"group":    opts.Group,
+		"event":    opts.Event,
+	}
+	if opts.Sound != "" {
+		details["sound"] = opts.Sound
+	}
+	if opts.Sender != "" {
+		details["sender"] = opts.Sender
+	}
+	return Action{
+		Kind:    "notification",
+		Handler: handler,
+		Summary: fmt.Sprintf("notify %q: %s", opts.Title, opts.Message),
+		Details: details,
+	}
+}
+
+// normalizeEvent accepts event names case-insensitively and with dashes
+// (e.g. "post-tool-use") and returns the canonical Claude Code name.
+func normalizeEvent(event string) string {
+	key := strings.ToLower(strings.ReplaceAll(event, "-", ""))
+	for _, e := range Events {
+		if strings.ToLower(e) == key {
+			return e
+		}
+	}
+	return event
+}
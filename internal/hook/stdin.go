@@ -15,6 +15,8 @@ type HookInput struct { //nolint:revive // Stuttering is acceptable for exported
 	TranscriptPath string                `json:"transcript_path"`
 	Message       string                 `json:"message"`
 	NotificationType string             `json:"notification_type"`
+	HookEventName string                `json:"hook_event_name"`
+	ToolName      string                `json:"tool_name"`
 }
 
 // GetStdinData reads stdin JSON data from hooks.
@@ -33,11 +35,15 @@ func GetStdinData() (*HookInput, error) {
 		return nil, nil
 	}
 
+	return ParseHookInput(data)
+}
+
+// ParseHookInput parses a hook JSON payload.
+func ParseHookInput(data []byte) (*HookInput, error) {
 	var input HookInput
 	if err := json.Unmarshal(data, &input); err != nil {
 		return nil, err
 	}
-
 	return &input, nil
 }
 
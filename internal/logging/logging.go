@@ -0,0 +1,186 @@
+// Package logging configures the process-wide slog logger: a terse,
+// human-readable console handler on stderr plus an optional JSON log file
+// that always records debug detail.
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ryantking/agentctl/internal/fsutil"
+)
+
+// FileEnvVar names a JSON log file to append to, for hooks where flags
+// cannot be added.
+const FileEnvVar = "AGENTCTL_LOG_FILE"
+
+// Options selects the console verbosity and log file.
+type Options struct {
+	Verbose bool
+	Debug   bool
+	Quiet   bool
+	// File is a path to append JSON log records to. Empty disables the file.
+	File string
+}
+
+// Level returns the console level implied by the options. Debug wins over
+// Verbose, which wins over Quiet; the default shows warnings and errors.
+func (o Options) Level() slog.Level {
+	switch {
+	case o.Debug:
+		return slog.LevelDebug
+	case o.Verbose:
+		return slog.LevelInfo
+	case o.Quiet:
+		return slog.LevelError
+	default:
+		return slog.LevelWarn
+	}
+}
+
+// Setup installs the default slog logger and returns a function that closes
+// the log file, if any.
+func Setup(opts Options) (func() error, error) {
+	handlers := []slog.Handler{NewConsoleHandler(os.Stderr, opts.Level())}
+	closeFn := func() error { return nil }
+
+	if opts.File != "" {
+		if err := fsutil.MkdirAll(filepath.Dir(opts.File)); err != nil {
+			return closeFn, err
+		}
+		f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, fsutil.FileMode())
+		if err != nil {
+			return closeFn, fmt.Errorf("failed to open log file: %w", err)
+		}
+		handlers = append(handlers, slog.NewJSONHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		closeFn = f.Close
+	}
+
+	slog.SetDefault(slog.New(fanout(handlers)))
+	return closeFn, nil
+}
+
+// ConsoleHandler writes records as "Level: message key=value ..." lines,
+// matching the "Error: ..." and "Warning: ..." style of other CLI output.
+type ConsoleHandler struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	level  slog.Leveler
+	attrs  []slog.Attr
+	groups []string
+}
+
+// NewConsoleHandler returns a handler writing records at or above level to w.
+func NewConsoleHandler(w io.Writer, level slog.Leveler) *ConsoleHandler {
+	return &ConsoleHandler{mu: &sync.Mutex{}, w: w, level: level}
+}
+
+// Enabled implements slog.Handler.
+func (h *ConsoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle implements slog.Handler.
+func (h *ConsoleHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	switch {
+	case r.Level >= slog.LevelError:
+		b.WriteString("Error: ")
+	case r.Level >= slog.LevelWarn:
+		b.WriteString("Warning: ")
+	case r.Level < slog.LevelInfo:
+		b.WriteString("Debug: ")
+	}
+	b.WriteString(r.Message)
+
+	prefix := ""
+	if len(h.groups) > 0 {
+		prefix = strings.Join(h.groups, ".") + "."
+	}
+	writeAttr := func(a slog.Attr) {
+		if a.Equal(slog.Attr{}) {
+			return
+		}
+		fmt.Fprintf(&b, " %s%s=%s", prefix, a.Key, formatValue(a.Value))
+	}
+	for _, a := range h.attrs {
+		writeAttr(a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		writeAttr(a)
+		return true
+	})
+	b.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+// WithAttrs implements slog.Handler.
+func (h *ConsoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(append([]slog.Attr(nil), h.attrs...), attrs...)
+	return &clone
+}
+
+// WithGroup implements slog.Handler.
+func (h *ConsoleHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.groups = append(append([]string(nil), h.groups...), name)
+	return &clone
+}
+
+func formatValue(v slog.Value) string {
+	s := v.Resolve().String()
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+// fanout sends each record to every handler that accepts its level.
+type fanout []slog.Handler
+
+func (f fanout) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range f {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f fanout) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range f {
+		if h.Enabled(ctx, r.Level) {
+			errs = append(errs, h.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (f fanout) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(fanout, len(f))
+	for i, h := range f {
+		out[i] = h.WithAttrs(attrs)
+	}
+	return out
+}
+
+func (f fanout) WithGroup(name string) slog.Handler {
+	out := make(fanout, len(f))
+	for i, h := range f {
+		out[i] = h.WithGroup(name)
+	}
+	return out
+}
@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConsoleHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewConsoleHandler(&buf, slog.LevelInfo))
+
+	logger.Debug("hidden")
+	logger.Info("copied files", "count", 3)
+	logger.With("repo", "my repo").Warn("fetch failed", "err", "exit status 1")
+
+	want := "copied files count=3\nWarning: fetch failed repo=\"my repo\" err=\"exit status 1\"\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestSetupLogFile(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	path := filepath.Join(t.TempDir(), "logs", "agentctl.log")
+	closeLog, err := Setup(Options{Quiet: true, File: path})
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	slog.Debug("git", "args", "status")
+	if err := closeLog(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(string(data))), &record); err != nil {
+		t.Fatalf("log line is not JSON: %v", err)
+	}
+	if record["msg"] != "git" || record["level"] != "DEBUG" {
+		t.Errorf("record = %v", record)
+	}
+}
@@ -0,0 +1,209 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/fsutil"
+	"github.com/ryantking/agentctl/internal/trace"
+)
+
+// CatalogEntry summarizes a tool or prompt offered by a server.
+type CatalogEntry struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Catalog is the cached tool and prompt listing for one server.
+type Catalog struct {
+	Server      string         `json:"server"`
+	RefreshedAt time.Time      `json:"refreshed_at"`
+	Tools       []CatalogEntry `json:"tools,omitempty"`
+	Prompts     []CatalogEntry `json:"prompts,omitempty"`
+	Error       string         `json:"error,omitempty"`
+}
+
+// RefreshOptions controls how catalogs are fetched.
+type RefreshOptions struct {
+	// MaxAge skips servers whose cached catalog is newer than this.
+	MaxAge time.Duration
+	// Timeout bounds each server connection.
+	Timeout time.Duration
+	// Concurrency limits how many servers are contacted at once.
+	Concurrency int
+}
+
+// DefaultRefreshOptions returns the options used by mcp refresh.
+func DefaultRefreshOptions() RefreshOptions {
+	return RefreshOptions{
+		MaxAge:      time.Hour,
+		Timeout:     20 * time.Second,
+		Concurrency: 4,
+	}
+}
+
+// CachePath returns where catalogs for this config are cached.
+// Catalogs live in the user cache directory, keyed by config path, so
+// refreshing never writes into the repository.
+func (c *Config) CachePath() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(c.path))
+	return filepath.Join(cacheDir, "agentctl", "mcp", hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// Catalogs returns the cached catalogs keyed by server name.
+func (c *Config) Catalogs() (map[string]Catalog, error) {
+	path, err := c.CachePath()
+	if err != nil {
+		return nil, err
+	}
+	catalogs := make(map[string]Catalog)
+	data, err := os.ReadFile(path) //nolint:gosec // Path is derived from the user cache directory
+	if os.IsNotExist(err) {
+		return catalogs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &catalogs); err != nil {
+		// A corrupt cache is not worth failing over; it will be rebuilt
+		return make(map[string]Catalog), nil
+	}
+	return catalogs, nil
+}
+
+// Refresh connects to each server whose cached catalog is older than
+// opts.MaxAge, fetches its tools and prompts, and updates the cache.
+// Returns the catalogs for all requested servers and the names that were refreshed.
+func (c *Config) Refresh(ctx context.Context, servers []Server, opts RefreshOptions) ([]Catalog, []string, error) {
+	cached, err := c.Catalogs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var stale []Server
+	for _, server := range servers {
+		if catalog, ok := cached[server.Name]; ok && catalog.Error == "" && time.Since(catalog.RefreshedAt) < opts.MaxAge {
+			continue
+		}
+		stale = append(stale, server)
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	span := trace.StartSpan(trace.KindMCP, "refresh catalogs")
+	results := make([]Catalog, len(stale))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, server := range stale {
+		wg.Add(1)
+		go func(i int, server Server) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			serverSpan := span.Child(trace.KindMCP, server.Name)
+			results[i] = fetchCatalog(ctx, server, opts.Timeout)
+			var err error
+			if results[i].Error != "" {
+				err = errors.New(results[i].Error)
+			}
+			serverSpan.End(err)
+		}(i, server)
+	}
+	wg.Wait()
+	span.End(nil)
+
+	refreshed := make([]string, 0, len(results))
+	for _, catalog := range results {
+		cached[catalog.Server] = catalog
+		refreshed = append(refreshed, catalog.Server)
+	}
+
+	// Drop catalogs for servers that are no longer configured. servers may
+	// be a subset being refreshed, so prune against the full config.
+	all, err := c.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	configured := make(map[string]bool, len(all))
+	for _, server := range all {
+		configured[server.Name] = true
+	}
+	for name := range cached {
+		if !configured[name] {
+			delete(cached, name)
+		}
+	}
+
+	if err := c.saveCatalogs(cached); err != nil {
+		return nil, nil, err
+	}
+
+	catalogs := make([]Catalog, 0, len(servers))
+	for _, server := range servers {
+		catalogs = append(catalogs, cached[server.Name])
+	}
+	return catalogs, refreshed, nil
+}
+
+func (c *Config) saveCatalogs(catalogs map[string]Catalog) error {
+	path, err := c.CachePath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(catalogs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := fsutil.MkdirAll(filepath.Dir(path)); err != nil {
+		return err
+	}
+	return fsutil.WriteFile(path, data, fsutil.FileMode())
+}
+
+// fetchCatalog lists a single server's tools and prompts.
+// Connection failures are recorded in the catalog rather than returned.
+func fetchCatalog(ctx context.Context, server Server, timeout time.Duration) Catalog {
+	catalog := Catalog{Server: server.Name, RefreshedAt: time.Now().UTC()}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	c, err := newClient(ctx, server)
+	if err != nil {
+		catalog.Error = err.Error()
+		return catalog
+	}
+	defer func() { _ = c.close() }()
+
+	hasPrompts, err := c.initialize(ctx)
+	if err != nil {
+		catalog.Error = err.Error()
+		return catalog
+	}
+
+	catalog.Tools, err = c.listAll(ctx, "tools/list", "tools")
+	if err != nil {
+		catalog.Error = err.Error()
+		return catalog
+	}
+
+	if hasPrompts {
+		// Prompts are optional; a failure here shouldn't discard the tool list
+		catalog.Prompts, _ = c.listAll(ctx, "prompts/list", "prompts")
+	}
+
+	return catalog
+}
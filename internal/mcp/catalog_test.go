@@ -0,0 +1,50 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRefreshSubsetKeepsOtherCatalogs(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	cfg := NewProjectConfig(t.TempDir())
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		if err := cfg.Add(Server{Name: name, Type: TypeStdio, Command: "agentctl-test-missing-binary"}, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	refreshedAt := time.Now().UTC().Add(-time.Minute)
+	if err := cfg.saveCatalogs(map[string]Catalog{
+		"alpha":   {Server: "alpha", RefreshedAt: refreshedAt},
+		"beta":    {Server: "beta", RefreshedAt: refreshedAt, Tools: []CatalogEntry{{Name: "search"}}},
+		"gamma":   {Server: "gamma", RefreshedAt: refreshedAt},
+		"removed": {Server: "removed", RefreshedAt: refreshedAt},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := RefreshOptions{Timeout: 5 * time.Second, Concurrency: 1}
+	catalogs, refreshed, err := cfg.Refresh(context.Background(), []Server{{Name: "alpha", Type: TypeStdio, Command: "agentctl-test-missing-binary"}}, opts)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if len(refreshed) != 1 || refreshed[0] != "alpha" || len(catalogs) != 1 || catalogs[0].Error == "" {
+		t.Errorf("Refresh() = %+v, %v; want a failed catalog for alpha only", catalogs, refreshed)
+	}
+
+	cached, err := cfg.Catalogs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cached["beta"].Tools) != 1 {
+		t.Errorf("refreshing alpha dropped beta's catalog: %+v", cached)
+	}
+	if _, ok := cached["gamma"]; !ok {
+		t.Errorf("refreshing alpha dropped gamma's catalog")
+	}
+	if _, ok := cached["removed"]; ok {
+		t.Errorf("catalog for an unconfigured server was kept")
+	}
+}
@@ -0,0 +1,377 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+)
+
+// protocolVersion is the MCP protocol revision agentctl speaks.
+const protocolVersion = "2025-03-26"
+
+// rpcRequest is a JSON-RPC 2.0 request or notification (when ID is nil).
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      *int64      `json:"id,omitempty"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response.
+type rpcResponse struct {
+	ID     *int64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// transport sends JSON-RPC messages to a server.
+type transport interface {
+	// call sends a request and waits for the response with the same ID.
+	call(ctx context.Context, req rpcRequest) (json.RawMessage, error)
+	// notify sends a notification that has no response.
+	notify(ctx context.Context, req rpcRequest) error
+	close() error
+}
+
+// client is a minimal MCP client used to read server catalogs.
+type client struct {
+	transport transport
+	nextID    int64
+}
+
+func newClient(ctx context.Context, server Server) (*client, error) {
+	var t transport
+	var err error
+	switch server.Type {
+	case TypeStdio:
+		t, err = newStdioTransport(ctx, server)
+	case TypeHTTP:
+		t = &httpTransport{url: server.URL, client: http.DefaultClient}
+	case TypeSSE:
+		return nil, fmt.Errorf("sse transport is not supported for catalog refresh")
+	default:
+		return nil, fmt.Errorf("unknown server type %q", server.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &client{transport: t}, nil
+}
+
+func (c *client) request(ctx context.Context, method string, params interface{}) (json.RawMessage, error) {
+	id := atomic.AddInt64(&c.nextID, 1)
+	slog.Debug("mcp request", "method", method, "id", id)
+	return c.transport.call(ctx, rpcRequest{JSONRPC: "2.0", ID: &id, Method: method, Params: params})
+}
+
+// initialize performs the MCP handshake and reports whether the server offers prompts.
+func (c *client) initialize(ctx context.Context) (bool, error) {
+	result, err := c.request(ctx, "initialize", map[string]interface{}{
+		"protocolVersion": protocolVersion,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]interface{}{"name": "agentctl", "version": "dev"},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var init struct {
+		Capabilities struct {
+			Prompts json.RawMessage `json:"prompts"`
+		} `json:"capabilities"`
+	}
+	if err := json.Unmarshal(result, &init); err != nil {
+		return false, fmt.Errorf("invalid initialize result: %w", err)
+	}
+
+	if err := c.transport.notify(ctx, rpcRequest{JSONRPC: "2.0", Method: "notifications/initialized"}); err != nil {
+		return false, err
+	}
+	return len(init.Capabilities.Prompts) > 0, nil
+}
+
+// listAll pages through a list method (tools/list, prompts/list) collecting
+// the entries under key.
+func (c *client) listAll(ctx context.Context, method, key string) ([]CatalogEntry, error) {
+	var entries []CatalogEntry
+	cursor := ""
+	for {
+		var params interface{}
+		if cursor != "" {
+			params = map[string]interface{}{"cursor": cursor}
+		}
+		result, err := c.request(ctx, method, params)
+		if err != nil {
+			return entries, err
+		}
+
+		var page map[string]json.RawMessage
+		if err := json.Unmarshal(result, &page); err != nil {
+			return entries, fmt.Errorf("invalid %s result: %w", method, err)
+		}
+		var items []CatalogEntry
+		if raw, ok := page[key]; ok {
+			if err := json.Unmarshal(raw, &items); err != nil {
+				return entries, fmt.Errorf("invalid %s result: %w", method, err)
+			}
+		}
+		entries = append(entries, items...)
+
+		cursor = ""
+		if raw, ok := page["nextCursor"]; ok {
+			_ = json.Unmarshal(raw, &cursor)
+		}
+		if cursor == "" {
+			return entries, nil
+		}
+	}
+}
+
+func (c *client) close() error {
+	return c.transport.close()
+}
+
+// stdioTransport talks to a server process over newline-delimited JSON on stdin/stdout.
+// A single reader goroutine owns stdout for the life of the transport and
+// hands responses to whichever call is waiting, so a call that gives up on
+// its context leaves nothing behind.
+type stdioTransport struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	responses chan rpcResponse
+	done      chan struct{}
+	// readErr is why the reader stopped; it is set before responses is closed.
+	readErr error
+}
+
+func newStdioTransport(ctx context.Context, server Server) (*stdioTransport, error) {
+	cmd := exec.CommandContext(ctx, server.Command, server.Args...) //nolint:gosec // Command comes from the user's MCP config
+	cmd.Env = os.Environ()
+	for k, v := range server.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	cmd.Stderr = io.Discard
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", server.Command, err)
+	}
+
+	t := newStdioPipes(stdin, stdout)
+	t.cmd = cmd
+	return t, nil
+}
+
+// newStdioPipes returns a transport over an already connected server and
+// starts its reader.
+func newStdioPipes(stdin io.WriteCloser, stdout io.Reader) *stdioTransport {
+	t := &stdioTransport{
+		stdin:     stdin,
+		responses: make(chan rpcResponse),
+		done:      make(chan struct{}),
+	}
+	go t.read(stdout)
+	return t
+}
+
+// read delivers responses from stdout until the server exits or the
+// transport is closed.
+func (t *stdioTransport) read(stdout io.Reader) {
+	defer close(t.responses)
+	reader := bufio.NewReader(stdout)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			t.readErr = err
+			return
+		}
+
+		var resp rpcResponse
+		if err := json.Unmarshal(line, &resp); err != nil || resp.ID == nil {
+			// Skip log lines and notifications
+			continue
+		}
+		select {
+		case t.responses <- resp:
+		case <-t.done:
+			return
+		}
+	}
+}
+
+func (t *stdioTransport) send(req rpcRequest) error {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	_, err = t.stdin.Write(append(data, '\n'))
+	return err
+}
+
+func (t *stdioTransport) call(ctx context.Context, req rpcRequest) (json.RawMessage, error) {
+	if err := t.send(req); err != nil {
+		return nil, err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case resp, ok := <-t.responses:
+			if !ok {
+				return nil, fmt.Errorf("server closed connection: %w", t.readErr)
+			}
+			if *resp.ID != *req.ID {
+				// Late responses to abandoned calls and server-initiated requests
+				continue
+			}
+			return resp.result()
+		}
+	}
+}
+
+func (t *stdioTransport) notify(_ context.Context, req rpcRequest) error {
+	return t.send(req)
+}
+
+func (t *stdioTransport) close() error {
+	close(t.done)
+	_ = t.stdin.Close()
+	if t.cmd != nil {
+		if t.cmd.Process != nil {
+			_ = t.cmd.Process.Kill()
+		}
+		_ = t.cmd.Wait()
+	}
+	return nil
+}
+
+// httpTransport implements the MCP streamable HTTP transport, accepting either
+// a JSON body or a text/event-stream response.
+type httpTransport struct {
+	url       string
+	client    *http.Client
+	sessionID string
+}
+
+func (t *httpTransport) post(ctx context.Context, req rpcRequest) (*http.Response, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json, text/event-stream")
+	if t.sessionID != "" {
+		httpReq.Header.Set("Mcp-Session-Id", t.sessionID)
+	}
+
+	resp, err := t.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	if id := resp.Header.Get("Mcp-Session-Id"); id != "" {
+		t.sessionID = id
+	}
+	if resp.StatusCode >= 300 {
+		_ = resp.Body.Close()
+		if resp.StatusCode == http.StatusUnauthorized {
+			return nil, fmt.Errorf("server requires authentication (HTTP 401)")
+		}
+		return nil, fmt.Errorf("server returned HTTP %d", resp.StatusCode)
+	}
+	return resp, nil
+}
+
+func (t *httpTransport) call(ctx context.Context, req rpcRequest) (json.RawMessage, error) {
+	resp, err := t.post(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return readEventStream(resp.Body, *req.ID)
+	}
+
+	var rpcResp rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	return rpcResp.result()
+}
+
+func (t *httpTransport) notify(ctx context.Context, req rpcRequest) error {
+	resp, err := t.post(ctx, req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (t *httpTransport) close() error {
+	return nil
+}
+
+// readEventStream reads SSE events until it finds the response with the given ID.
+func readEventStream(body io.Reader, id int64) (json.RawMessage, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "data:") {
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+			continue
+		}
+		if line != "" || data.Len() == 0 {
+			continue
+		}
+
+		// Blank line ends an event
+		var resp rpcResponse
+		if err := json.Unmarshal([]byte(data.String()), &resp); err == nil && resp.ID != nil && *resp.ID == id {
+			return resp.result()
+		}
+		data.Reset()
+	}
+	if data.Len() > 0 {
+		var resp rpcResponse
+		if err := json.Unmarshal([]byte(data.String()), &resp); err == nil && resp.ID != nil && *resp.ID == id {
+			return resp.result()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("event stream ended without a response")
+}
+
+func (r rpcResponse) result() (json.RawMessage, error) {
+	if r.Error != nil {
+		return nil, fmt.Errorf("server error %d: %s", r.Error.Code, r.Error.Message)
+	}
+	return r.Result, nil
+}
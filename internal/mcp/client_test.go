@@ -0,0 +1,239 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadEventStream(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "single event",
+			body: "event: message\ndata: {\"jsonrpc\":\"2.0\",\"id\":1,\"result\":{\"ok\":true}}\n\n",
+			want: `{"ok":true}`,
+		},
+		{
+			name: "skips other ids and notifications",
+			body: "data: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\"}\n\n" +
+				"data: {\"jsonrpc\":\"2.0\",\"id\":7,\"result\":{}}\n\n" +
+				"data: {\"jsonrpc\":\"2.0\",\"id\":1,\"result\":{\"n\":1}}\n\n",
+			want: `{"n":1}`,
+		},
+		{
+			name: "data split across lines",
+			body: "data: {\"jsonrpc\":\"2.0\",\n" +
+				"data: \"id\":1,\"result\":[]}\n\n",
+			want: `[]`,
+		},
+		{
+			name: "final event without blank line",
+			body: "data: {\"jsonrpc\":\"2.0\",\"id\":1,\"result\":\"done\"}",
+			want: `"done"`,
+		},
+		{
+			name:    "server error",
+			body:    "data: {\"jsonrpc\":\"2.0\",\"id\":1,\"error\":{\"code\":-32601,\"message\":\"no such method\"}}\n\n",
+			wantErr: "server error -32601: no such method",
+		},
+		{
+			name:    "no response",
+			body:    ": keepalive\n\n",
+			wantErr: "ended without a response",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readEventStream(strings.NewReader(tt.body), 1)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("readEventStream() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readEventStream() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("readEventStream() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeStdioServer connects a transport to an in-process server. handle is
+// called with each request that has an ID and returns the lines to write back.
+func fakeStdioServer(t *testing.T, handle func(req rpcRequest) []string) *stdioTransport {
+	t.Helper()
+	reqR, reqW := io.Pipe()
+	respR, respW := io.Pipe()
+	go func() {
+		defer func() { _ = respW.Close() }()
+		scanner := bufio.NewScanner(reqR)
+		for scanner.Scan() {
+			var req rpcRequest
+			if err := json.Unmarshal(scanner.Bytes(), &req); err != nil || req.ID == nil {
+				continue
+			}
+			for _, line := range handle(req) {
+				if _, err := io.WriteString(respW, line+"\n"); err != nil {
+					return
+				}
+			}
+		}
+	}()
+	tr := newStdioPipes(reqW, respR)
+	t.Cleanup(func() { _ = tr.close() })
+	return tr
+}
+
+func request(id int64, method string) rpcRequest {
+	return rpcRequest{JSONRPC: "2.0", ID: &id, Method: method}
+}
+
+func TestStdioTransportSkipsNoise(t *testing.T) {
+	tr := fakeStdioServer(t, func(req rpcRequest) []string {
+		return []string{
+			"starting server...",
+			`{"jsonrpc":"2.0","method":"notifications/message","params":{}}`,
+			fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":{"method":%q}}`, *req.ID, req.Method),
+		}
+	})
+
+	for i, method := range []string{"initialize", "tools/list"} {
+		got, err := tr.call(context.Background(), request(int64(i+1), method))
+		if err != nil {
+			t.Fatalf("call(%s) error = %v", method, err)
+		}
+		if want := fmt.Sprintf(`{"method":%q}`, method); string(got) != want {
+			t.Errorf("call(%s) = %s, want %s", method, got, want)
+		}
+	}
+}
+
+func TestStdioTransportLateResponse(t *testing.T) {
+	release := make(chan struct{})
+	tr := fakeStdioServer(t, func(req rpcRequest) []string {
+		if *req.ID == 1 {
+			// Hold the first response until the caller has given up on it
+			<-release
+			return []string{`{"jsonrpc":"2.0","id":1,"result":"stale"}`}
+		}
+		return []string{fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":"fresh"}`, *req.ID)}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := tr.call(ctx, request(1, "slow")); err != context.DeadlineExceeded {
+		t.Fatalf("call() error = %v, want deadline exceeded", err)
+	}
+	close(release)
+
+	got, err := tr.call(context.Background(), request(2, "fast"))
+	if err != nil {
+		t.Fatalf("call() after timeout error = %v", err)
+	}
+	if string(got) != `"fresh"` {
+		t.Errorf("call() after timeout = %s, want the response to its own request", got)
+	}
+}
+
+func TestStdioTransportTimeoutsDoNotLeak(t *testing.T) {
+	tr := fakeStdioServer(t, func(rpcRequest) []string { return nil })
+	before := runtime.NumGoroutine()
+
+	const calls = 20
+	for i := int64(1); i <= calls; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		_, err := tr.call(ctx, request(i, "tools/list"))
+		cancel()
+		if err != context.DeadlineExceeded {
+			t.Fatalf("call() error = %v, want deadline exceeded", err)
+		}
+	}
+
+	if after := runtime.NumGoroutine(); after-before >= calls/2 {
+		t.Errorf("goroutines grew from %d to %d over %d timed-out calls", before, after, calls)
+	}
+}
+
+func TestStdioTransportServerExit(t *testing.T) {
+	reqR, reqW := io.Pipe()
+	respR, respW := io.Pipe()
+	go func() { _, _ = io.Copy(io.Discard, reqR) }()
+	tr := newStdioPipes(reqW, respR)
+	defer func() { _ = tr.close() }()
+
+	_ = respW.Close()
+	if _, err := tr.call(context.Background(), request(1, "initialize")); err == nil || !strings.Contains(err.Error(), "server closed connection") {
+		t.Errorf("call() error = %v, want server closed connection", err)
+	}
+}
+
+func TestFetchCatalogHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.ID == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		if req.Method != "initialize" && r.Header.Get("Mcp-Session-Id") != "session-1" {
+			http.Error(w, "missing session", http.StatusBadRequest)
+			return
+		}
+
+		var result string
+		switch req.Method {
+		case "initialize":
+			w.Header().Set("Mcp-Session-Id", "session-1")
+			result = `{"protocolVersion":"2025-03-26","capabilities":{"tools":{},"prompts":{}}}`
+		case "tools/list":
+			params, _ := req.Params.(map[string]interface{})
+			if params["cursor"] == "page-2" {
+				result = `{"tools":[{"name":"fetch"}]}`
+			} else {
+				result = `{"tools":[{"name":"search","description":"Search docs"}],"nextCursor":"page-2"}`
+			}
+		case "prompts/list":
+			// Answer over an event stream to cover both response framings
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%d,\"result\":{\"prompts\":[{\"name\":\"summarize\"}]}}\n\n", *req.ID)
+			return
+		default:
+			result = `{}`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":%s}`, *req.ID, result)
+	}))
+	defer srv.Close()
+
+	catalog := fetchCatalog(context.Background(), Server{Name: "docs", Type: TypeHTTP, URL: srv.URL}, 5*time.Second)
+	if catalog.Error != "" {
+		t.Fatalf("fetchCatalog() error = %s", catalog.Error)
+	}
+	wantTools := []CatalogEntry{{Name: "search", Description: "Search docs"}, {Name: "fetch"}}
+	if fmt.Sprint(catalog.Tools) != fmt.Sprint(wantTools) {
+		t.Errorf("tools = %+v, want %+v", catalog.Tools, wantTools)
+	}
+	if len(catalog.Prompts) != 1 || catalog.Prompts[0].Name != "summarize" {
+		t.Errorf("prompts = %+v, want [summarize]", catalog.Prompts)
+	}
+}
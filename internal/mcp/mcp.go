@@ -0,0 +1,276 @@
+// Package mcp provides management of MCP server entries in Claude Code configuration files.
+package mcp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/config"
+)
+
+// Server transport types supported by Claude Code.
+const (
+	TypeHTTP  = "http"
+	TypeSSE   = "sse"
+	TypeStdio = "stdio"
+)
+
+var (
+	// ErrServerExists indicates a server with the same name is already configured.
+	ErrServerExists = fmt.Errorf("MCP server already exists")
+	// ErrServerNotFound indicates the named server is not configured.
+	ErrServerNotFound = fmt.Errorf("MCP server not found")
+)
+
+// Server represents a single MCP server entry.
+type Server struct {
+	Name    string            `json:"name"`
+	Type    string            `json:"type"`
+	URL     string            `json:"url,omitempty"`
+	Command string            `json:"command,omitempty"`
+	Args    []string          `json:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty"`
+}
+
+// Validate checks that the server has the fields its transport type requires.
+func (s Server) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("server name is required")
+	}
+	if strings.ContainsAny(s.Name, " /\\") {
+		return fmt.Errorf("invalid server name %q: must not contain spaces or slashes", s.Name)
+	}
+	switch s.Type {
+	case TypeHTTP, TypeSSE:
+		if s.URL == "" {
+			return fmt.Errorf("--url is required for %s servers", s.Type)
+		}
+		if !strings.HasPrefix(s.URL, "http://") && !strings.HasPrefix(s.URL, "https://") {
+			return fmt.Errorf("invalid URL %q: must start with http:// or https://", s.URL)
+		}
+		if s.Command != "" {
+			return fmt.Errorf("--command is only valid for stdio servers")
+		}
+	case TypeStdio:
+		if s.Command == "" {
+			return fmt.Errorf("--command is required for stdio servers")
+		}
+		if s.URL != "" {
+			return fmt.Errorf("--url is only valid for http and sse servers")
+		}
+	default:
+		return fmt.Errorf("invalid server type %q: must be http, sse, or stdio", s.Type)
+	}
+	return nil
+}
+
+// ToMap converts the server to its JSON config representation (without the name).
+func (s Server) ToMap() map[string]interface{} {
+	entry := map[string]interface{}{
+		"type": s.Type,
+	}
+	if s.URL != "" {
+		entry["url"] = s.URL
+	}
+	if s.Command != "" {
+		entry["command"] = s.Command
+	}
+	if len(s.Args) > 0 {
+		args := make([]interface{}, len(s.Args))
+		for i, arg := range s.Args {
+			args[i] = arg
+		}
+		entry["args"] = args
+	}
+	if len(s.Env) > 0 {
+		env := make(map[string]interface{}, len(s.Env))
+		for k, v := range s.Env {
+			env[k] = v
+		}
+		entry["env"] = env
+	}
+	return entry
+}
+
+// serverFromMap converts a JSON config entry back into a Server.
+func serverFromMap(name string, raw interface{}) Server {
+	server := Server{Name: name}
+	entry, ok := raw.(map[string]interface{})
+	if !ok {
+		return server
+	}
+	server.Type, _ = entry["type"].(string)
+	server.URL, _ = entry["url"].(string)
+	server.Command, _ = entry["command"].(string)
+	if server.Type == "" && server.Command != "" {
+		// Claude Code treats entries without a type but with a command as stdio
+		server.Type = TypeStdio
+	}
+	if args, ok := entry["args"].([]interface{}); ok {
+		for _, arg := range args {
+			if s, ok := arg.(string); ok {
+				server.Args = append(server.Args, s)
+			}
+		}
+	}
+	if env, ok := entry["env"].(map[string]interface{}); ok {
+		server.Env = make(map[string]string, len(env))
+		for k, v := range env {
+			if s, ok := v.(string); ok {
+				server.Env[k] = s
+			}
+		}
+	}
+	return server
+}
+
+// DefaultServers returns the MCP servers installed by agentctl init.
+func DefaultServers() []Server {
+	return []Server{
+		{Name: "context7", Type: TypeHTTP, URL: "https://mcp.context7.com/mcp"},
+		{Name: "linear", Type: TypeSSE, URL: "https://mcp.linear.app/sse"},
+	}
+}
+
+// Config manages the MCP server section of a Claude Code config file.
+// For a repository this is <root>/.mcp.json, and servers are also enabled in
+// <root>/.claude/settings.json. Globally it is ~/.claude.json.
+type Config struct {
+	path         string
+	settingsPath string
+}
+
+// NewProjectConfig returns the MCP config for a repository root.
+func NewProjectConfig(repoRoot string) *Config {
+	return &Config{
+		path:         filepath.Join(repoRoot, ".mcp.json"),
+		settingsPath: filepath.Join(repoRoot, ".claude", "settings.json"),
+	}
+}
+
+// NewGlobalConfig returns the user-scoped MCP config in ~/.claude.json.
+func NewGlobalConfig() (*Config, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return &Config{path: filepath.Join(home, ".claude.json")}, nil
+}
+
+// Path returns the path of the file holding the mcpServers section.
+func (c *Config) Path() string {
+	return c.path
+}
+
+// List returns all configured servers sorted by name.
+func (c *Config) List() ([]Server, error) {
+	data, err := config.LoadFile(c.path)
+	if err != nil {
+		return nil, err
+	}
+	servers := serversSection(data)
+	result := make([]Server, 0, len(servers))
+	for name, raw := range servers {
+		result = append(result, serverFromMap(name, raw))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// Add validates and adds a server. Existing servers are only replaced when force is true.
+func (c *Config) Add(server Server, force bool) error {
+	if err := server.Validate(); err != nil {
+		return err
+	}
+
+	data, err := config.LoadFile(c.path)
+	if err != nil {
+		return err
+	}
+	servers := serversSection(data)
+	if _, exists := servers[server.Name]; exists && !force {
+		return fmt.Errorf("%w: %s (use --force to replace)", ErrServerExists, server.Name)
+	}
+	servers[server.Name] = server.ToMap()
+	data["mcpServers"] = servers
+
+	if err := config.SaveFile(c.path, data); err != nil {
+		return err
+	}
+	return c.setEnabled(server.Name, true)
+}
+
+// Remove deletes a server by name.
+func (c *Config) Remove(name string) error {
+	data, err := config.LoadFile(c.path)
+	if err != nil {
+		return err
+	}
+	servers := serversSection(data)
+	if _, exists := servers[name]; !exists {
+		return fmt.Errorf("%w: %s", ErrServerNotFound, name)
+	}
+	delete(servers, name)
+	data["mcpServers"] = servers
+
+	if err := config.SaveFile(c.path, data); err != nil {
+		return err
+	}
+	return c.setEnabled(name, false)
+}
+
+// setEnabled adds or removes a server from enabledMcpjsonServers in the
+// project settings so Claude Code does not prompt to approve servers added
+// through agentctl. It is a no-op for global configs and when settings.json
+// does not exist.
+func (c *Config) setEnabled(name string, enabled bool) error {
+	if c.settingsPath == "" {
+		return nil
+	}
+	if _, err := os.Stat(c.settingsPath); os.IsNotExist(err) {
+		return nil
+	}
+
+	settings, err := config.LoadFile(c.settingsPath)
+	if err != nil {
+		return err
+	}
+
+	var names []interface{}
+	found := false
+	if existing, ok := settings["enabledMcpjsonServers"].([]interface{}); ok {
+		for _, item := range existing {
+			if item == name {
+				found = true
+				if !enabled {
+					continue
+				}
+			}
+			names = append(names, item)
+		}
+	}
+
+	switch {
+	case enabled && !found:
+		names = append(names, name)
+	case enabled == found:
+		return nil
+	}
+
+	if len(names) == 0 {
+		delete(settings, "enabledMcpjsonServers")
+	} else {
+		settings["enabledMcpjsonServers"] = names
+	}
+	return config.SaveFile(c.settingsPath, settings)
+}
+
+func serversSection(data map[string]interface{}) map[string]interface{} {
+	if servers, ok := data["mcpServers"].(map[string]interface{}); ok {
+		return servers
+	}
+	return make(map[string]interface{})
+}
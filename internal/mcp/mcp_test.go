@@ -0,0 +1,207 @@
+package mcp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ryantking/agentctl/internal/config"
+)
+
+func TestServerValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		server  Server
+		wantErr string
+	}{
+		{"http", Server{Name: "docs", Type: TypeHTTP, URL: "https://example.com/mcp"}, ""},
+		{"sse", Server{Name: "docs", Type: TypeSSE, URL: "http://localhost:8080/sse"}, ""},
+		{"stdio", Server{Name: "fs", Type: TypeStdio, Command: "npx", Args: []string{"server"}}, ""},
+		{"missing name", Server{Type: TypeHTTP, URL: "https://example.com"}, "name is required"},
+		{"name with space", Server{Name: "my docs", Type: TypeHTTP, URL: "https://example.com"}, "invalid server name"},
+		{"name with slash", Server{Name: "a/b", Type: TypeStdio, Command: "x"}, "invalid server name"},
+		{"http without url", Server{Name: "docs", Type: TypeHTTP}, "--url is required"},
+		{"url without scheme", Server{Name: "docs", Type: TypeSSE, URL: "example.com"}, "invalid URL"},
+		{"http with command", Server{Name: "docs", Type: TypeHTTP, URL: "https://example.com", Command: "x"}, "--command is only valid"},
+		{"stdio without command", Server{Name: "fs", Type: TypeStdio}, "--command is required"},
+		{"stdio with url", Server{Name: "fs", Type: TypeStdio, Command: "x", URL: "https://example.com"}, "--url is only valid"},
+		{"unknown type", Server{Name: "fs", Type: "grpc"}, "invalid server type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.server.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServerRoundTrip(t *testing.T) {
+	server := Server{Name: "fs", Type: TypeStdio, Command: "npx", Args: []string{"-y", "server"}, Env: map[string]string{"TOKEN": "${TOKEN}"}}
+	if got := serverFromMap("fs", server.ToMap()); !reflect.DeepEqual(got, server) {
+		t.Errorf("serverFromMap(ToMap()) = %+v, want %+v", got, server)
+	}
+
+	// Claude Code accepts stdio entries without a type
+	got := serverFromMap("legacy", map[string]interface{}{"command": "node"})
+	if got.Type != TypeStdio {
+		t.Errorf("untyped entry with a command has type %q, want %q", got.Type, TypeStdio)
+	}
+}
+
+func TestConfigAddRemove(t *testing.T) {
+	docs := Server{Name: "docs", Type: TypeHTTP, URL: "https://example.com/mcp"}
+	fs := Server{Name: "fs", Type: TypeStdio, Command: "npx"}
+
+	tests := []struct {
+		name        string
+		settings    map[string]interface{} // nil means no settings.json
+		run         func(*Config) error
+		wantErr     error
+		wantServers []string
+		wantEnabled []interface{}
+	}{
+		{
+			name:        "add without settings file",
+			run:         func(c *Config) error { return c.Add(docs, false) },
+			wantServers: []string{"docs"},
+		},
+		{
+			name:        "add enables in settings",
+			settings:    map[string]interface{}{"enabledMcpjsonServers": []interface{}{"other"}},
+			run:         func(c *Config) error { return c.Add(docs, false) },
+			wantServers: []string{"docs"},
+			wantEnabled: []interface{}{"other", "docs"},
+		},
+		{
+			name:     "add existing without force",
+			settings: map[string]interface{}{},
+			run: func(c *Config) error {
+				if err := c.Add(docs, false); err != nil {
+					return err
+				}
+				return c.Add(docs, false)
+			},
+			wantErr:     ErrServerExists,
+			wantServers: []string{"docs"},
+			wantEnabled: []interface{}{"docs"},
+		},
+		{
+			name:     "add existing with force does not duplicate",
+			settings: map[string]interface{}{},
+			run: func(c *Config) error {
+				if err := c.Add(docs, false); err != nil {
+					return err
+				}
+				return c.Add(docs, true)
+			},
+			wantServers: []string{"docs"},
+			wantEnabled: []interface{}{"docs"},
+		},
+		{
+			name:     "remove disables in settings",
+			settings: map[string]interface{}{"enabledMcpjsonServers": []interface{}{"other"}},
+			run: func(c *Config) error {
+				if err := c.Add(docs, false); err != nil {
+					return err
+				}
+				if err := c.Add(fs, false); err != nil {
+					return err
+				}
+				return c.Remove("docs")
+			},
+			wantServers: []string{"fs"},
+			wantEnabled: []interface{}{"other", "fs"},
+		},
+		{
+			name:     "remove last enabled drops the key",
+			settings: map[string]interface{}{},
+			run: func(c *Config) error {
+				if err := c.Add(docs, false); err != nil {
+					return err
+				}
+				return c.Remove("docs")
+			},
+			wantServers: []string{},
+		},
+		{
+			name:        "remove missing",
+			run:         func(c *Config) error { return c.Remove("docs") },
+			wantErr:     ErrServerNotFound,
+			wantServers: []string{},
+		},
+		{
+			name:        "add invalid",
+			run:         func(c *Config) error { return c.Add(Server{Name: "bad", Type: TypeHTTP}, false) },
+			wantErr:     errors.New("--url is required"),
+			wantServers: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			cfg := NewProjectConfig(root)
+			settingsPath := filepath.Join(root, ".claude", "settings.json")
+			if tt.settings != nil {
+				if err := config.SaveFile(settingsPath, tt.settings); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := tt.run(cfg)
+			switch {
+			case tt.wantErr == nil && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantErr != nil && err == nil:
+				t.Fatalf("error = nil, want %v", tt.wantErr)
+			case tt.wantErr != nil && !errors.Is(err, tt.wantErr) && !strings.Contains(err.Error(), tt.wantErr.Error()):
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+
+			servers, err := cfg.List()
+			if err != nil {
+				t.Fatal(err)
+			}
+			names := []string{}
+			for _, s := range servers {
+				names = append(names, s.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantServers) {
+				t.Errorf("servers = %v, want %v", names, tt.wantServers)
+			}
+
+			if tt.settings == nil {
+				if _, err := os.Stat(settingsPath); !os.IsNotExist(err) {
+					t.Errorf("settings.json was created")
+				}
+				return
+			}
+			settings, err := config.LoadFile(settingsPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			enabled, ok := settings["enabledMcpjsonServers"]
+			if tt.wantEnabled == nil {
+				if ok {
+					t.Errorf("enabledMcpjsonServers = %v, want unset", enabled)
+				}
+				return
+			}
+			if !reflect.DeepEqual(enabled, tt.wantEnabled) {
+				t.Errorf("enabledMcpjsonServers = %v, want %v", enabled, tt.wantEnabled)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Package memory reads agent memory files (CLAUDE.md, AGENTS.md) and the
+// files they pull in through @imports.
+package memory
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// MaxImportDepth is how many hops of @imports Claude Code follows.
+const MaxImportDepth = 5
+
+// importPattern matches @path references at the start of a line or after whitespace.
+var importPattern = regexp.MustCompile(`(?:^|\s)@([^\s` + "`" + `]+)`)
+
+// Node is a memory file and the files it imports.
+type Node struct {
+	Path     string  `json:"path"`
+	Import   string  `json:"import,omitempty"`
+	Lines    int     `json:"lines"`
+	Tokens   int     `json:"tokens"`
+	Missing  bool    `json:"missing,omitempty"`
+	Circular bool    `json:"circular,omitempty"`
+	TooDeep  bool    `json:"too_deep,omitempty"`
+	Imports  []*Node `json:"imports,omitempty"`
+}
+
+// ParseImports returns the @import references in content, in order.
+// References inside fenced code blocks and inline code spans are ignored,
+// matching how Claude Code expands imports.
+func ParseImports(content string) []string {
+	var imports []string
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for _, match := range importPattern.FindAllStringSubmatch(stripCodeSpans(line), -1) {
+			ref := strings.TrimRight(match[1], ".,;:)")
+			if ref != "" {
+				imports = append(imports, ref)
+			}
+		}
+	}
+	return imports
+}
+
+// BuildGraph walks @imports from each root file. Relative imports resolve
+// against the importing file's directory and ~/ against the home directory.
+// Missing roots are included and marked Missing.
+func BuildGraph(roots []string) []*Node {
+	nodes := make([]*Node, 0, len(roots))
+	for _, root := range roots {
+		nodes = append(nodes, walk(root, "", 0, map[string]bool{}))
+	}
+	return nodes
+}
+
+// Files returns the readable files in the graphs, depth first and without
+// duplicates: the memory files Claude Code would load.
+func Files(nodes []*Node) []string {
+	var files []string
+	seen := make(map[string]bool)
+	var visit func(*Node)
+	visit = func(node *Node) {
+		if node.Missing || node.Circular || seen[node.Path] {
+			return
+		}
+		seen[node.Path] = true
+		files = append(files, node.Path)
+		for _, child := range node.Imports {
+			visit(child)
+		}
+	}
+	for _, node := range nodes {
+		visit(node)
+	}
+	return files
+}
+
+func walk(path, ref string, depth int, ancestors map[string]bool) *Node {
+	node := &Node{Path: path, Import: ref}
+	if ancestors[path] {
+		node.Circular = true
+		return node
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // Paths come from memory files the user controls
+	if err != nil {
+		node.Missing = true
+		return node
+	}
+	content := string(data)
+	node.Lines = strings.Count(content, "\n")
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		node.Lines++
+	}
+	node.Tokens = EstimateTokens(content)
+
+	imports := ParseImports(content)
+	if len(imports) == 0 {
+		return node
+	}
+	if depth >= MaxImportDepth {
+		node.TooDeep = true
+		return node
+	}
+
+	ancestors[path] = true
+	for _, imp := range imports {
+		node.Imports = append(node.Imports, walk(resolveImport(path, imp), imp, depth+1, ancestors))
+	}
+	delete(ancestors, path)
+	return node
+}
+
+// resolveImport returns the file an @import in from refers to.
+func resolveImport(from, ref string) string {
+	if strings.HasPrefix(ref, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, ref[2:])
+		}
+	}
+	if filepath.IsAbs(ref) {
+		return filepath.Clean(ref)
+	}
+	return filepath.Join(filepath.Dir(from), ref)
+}
+
+// stripCodeSpans blanks out `inline code` so references inside it are ignored.
+func stripCodeSpans(line string) string {
+	parts := strings.Split(line, "`")
+	for i := 1; i < len(parts); i += 2 {
+		if i == len(parts)-1 {
+			// Unterminated span; keep the text as-is
+			break
+		}
+		parts[i] = ""
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,84 @@
+package memory
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseImports(t *testing.T) {
+	content := "See @docs/guide.md and @~/.claude/shared.md.\n" +
+		"Email noreply@example.com is not an import.\n" +
+		"Inline `@ignored.md` is skipped.\n" +
+		"```\n@also-ignored.md\n```\n" +
+		"@last.md\n"
+
+	got := ParseImports(content)
+	want := []string{"docs/guide.md", "~/.claude/shared.md", "last.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseImports() = %v, want %v", got, want)
+	}
+}
+
+func TestBuildGraph(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("CLAUDE.md", "# Root\n@docs/a.md\n@missing.md\n")
+	write("docs/a.md", "A\n@b.md\n")
+	write("docs/b.md", "B\n@a.md\n")
+
+	nodes := BuildGraph([]string{filepath.Join(dir, "CLAUDE.md")})
+	if len(nodes) != 1 {
+		t.Fatalf("BuildGraph() returned %d roots, want 1", len(nodes))
+	}
+	root := nodes[0]
+	if root.Lines != 3 || len(root.Imports) != 2 {
+		t.Fatalf("root = %d lines, %d imports; want 3 lines, 2 imports", root.Lines, len(root.Imports))
+	}
+
+	a := root.Imports[0]
+	if a.Path != filepath.Join(dir, "docs", "a.md") || a.Missing {
+		t.Errorf("a = %+v, want existing docs/a.md", a)
+	}
+	if !root.Imports[1].Missing {
+		t.Errorf("missing.md not marked missing")
+	}
+
+	b := a.Imports[0]
+	if b.Path != filepath.Join(dir, "docs", "b.md") {
+		t.Errorf("b.Path = %s, want imports resolved relative to the importing file", b.Path)
+	}
+	if len(b.Imports) != 1 || !b.Imports[0].Circular {
+		t.Errorf("b -> a not marked circular: %+v", b.Imports)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		min, max int
+	}{
+		{"empty", "", 0, 0},
+		{"prose", "The quick brown fox jumps over the lazy dog.", 9, 14},
+		{"code", "if (a[i] != b[j]) { return -1; }", 12, 20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EstimateTokens(tt.text)
+			if got < tt.min || got > tt.max {
+				t.Errorf("EstimateTokens(%q) = %d, want between %d and %d", tt.text, got, tt.min, tt.max)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// EstimateTokens approximates how many model tokens text uses.
+// It is a heuristic, not a tokenizer: roughly four characters per token for
+// prose, with a floor of one token per word and per punctuation mark, which
+// keeps markdown-heavy and code-heavy files from being undercounted.
+func EstimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+
+	words, punct := 0, 0
+	inWord := false
+	for _, r := range text {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				words++
+				inWord = true
+			}
+		case unicode.IsSpace(r):
+			inWord = false
+		default:
+			punct++
+			inWord = false
+		}
+	}
+
+	byChars := (utf8.RuneCountInString(text) + 3) / 4
+	if floor := words + punct; floor > byChars {
+		// Blend the two so dense text isn't charged one token per symbol
+		return (byChars + floor) / 2
+	}
+	return byChars
+}
+
+// TotalTokens sums the estimated tokens of each file in the graph, counting
+// a file imported more than once a single time.
+func TotalTokens(nodes []*Node) int {
+	seen := make(map[string]bool)
+	total := 0
+	var walk func(node *Node)
+	walk = func(node *Node) {
+		if node.Missing || node.Circular || seen[node.Path] {
+			return
+		}
+		seen[node.Path] = true
+		total += node.Tokens
+		for _, child := range node.Imports {
+			walk(child)
+		}
+	}
+	for _, node := range nodes {
+		walk(node)
+	}
+	return total
+}
@@ -1,9 +1,18 @@
-// Package notify provides macOS notification functionality with graceful fallback.
+// Package notify sends notifications through the local desktop and any
+// remote providers (Slack, Discord, generic webhooks) configured by the user.
 package notify
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os/exec"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/trace"
 )
 
 // Common sender bundle IDs for macOS notifications.
@@ -15,8 +24,19 @@ const (
 	SenderCursor = "com.todesktop.230313mzl4w4u92"
 )
 
+// Notification events, used to route notifications to providers.
+const (
+	EventInput = "input"
+	EventStop  = "stop"
+	EventError = "error"
+)
+
+// sendTimeout bounds delivery to all providers so hooks never hang.
+const sendTimeout = 10 * time.Second
+
 // Options contains notification options.
 type Options struct {
+	Event    string // One of the Event constants; empty matches every provider
 	Title    string
 	Subtitle string
 	Message  string
@@ -25,9 +45,67 @@ type Options struct {
 	Sender   string // macOS bundle ID for custom icon (e.g., "com.anthropic.claudefordesktop", "com.todesktop.230313mzl4w4u92")
 }
 
-// Send sends a macOS notification with the given options.
-// Uses terminal-notifier if available (supports custom sender/icons), otherwise falls back to osascript.
+// Send delivers a notification to every provider in the user's notification
+// config that accepts opts.Event, concurrently, unless quiet hours, the rate
+// limit, or duplicate collapsing hold it back. An unreadable config is logged
+// and only the desktop notification is sent.
 func Send(opts Options) error {
+	cfg, err := LoadConfig()
+	if err != nil {
+		slog.Warn("ignoring notification config", "err", err)
+		cfg = &Config{}
+	}
+
+	reason, forget, err := cfg.suppressReason(opts)
+	if err != nil {
+		slog.Warn("notification throttling unavailable", "err", err)
+	}
+	if reason != "" {
+		slog.Debug("notification suppressed", "reason", reason, "event", opts.Event, "group", opts.Group)
+		return nil
+	}
+
+	providers, err := cfg.ProvidersFor(opts.Event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+
+	errs := make([]error, len(providers))
+	var wg sync.WaitGroup
+	for i, provider := range providers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := provider.Send(ctx, opts); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", provider.Name(), err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if forget != nil && !slices.Contains(errs, nil) {
+		// Nothing was delivered, so don't let the attempt throttle a retry
+		if err := forget(); err != nil {
+			slog.Warn("failed to update notification throttle state", "err", err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Desktop sends local notifications on macOS.
+// Uses terminal-notifier if available (supports custom sender/icons), otherwise falls back to osascript.
+type Desktop struct{}
+
+// Name implements Provider.
+func (Desktop) Name() string {
+	return "desktop"
+}
+
+// Send implements Provider.
+func (Desktop) Send(_ context.Context, opts Options) error {
 	if hasTerminalNotifier() {
 		return sendWithTerminalNotifier(opts)
 	}
@@ -61,7 +139,10 @@ func sendWithTerminalNotifier(opts Options) error {
 	}
 
 	cmd := exec.Command("terminal-notifier", args...) //nolint:gosec // terminal-notifier is a trusted local binary
-	return cmd.Run()
+	span := trace.StartSpan(trace.KindExec, "terminal-notifier")
+	err := cmd.Run()
+	span.End(err)
+	return err
 }
 
 // sendWithOSAScript sends notification using osascript (fallback, no custom sender support).
@@ -73,7 +154,10 @@ func sendWithOSAScript(opts Options) error {
 	script := fmt.Sprintf(`display notification "%s" with title "%s" subtitle "%s"%s`,
 		opts.Message, opts.Title, opts.Subtitle, soundClause)
 	cmd := exec.Command("osascript", "-e", script) //nolint:gosec // osascript is a trusted system binary
-	return cmd.Run()
+	span := trace.StartSpan(trace.KindExec, "osascript")
+	err := cmd.Run()
+	span.End(err)
+	return err
 }
 
 // HasTerminalNotifier returns whether terminal-notifier is available.
@@ -0,0 +1,317 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recorder is a webhook endpoint that keeps every request body by path.
+type recorder struct {
+	mu     sync.Mutex
+	bodies map[string]map[string]string
+	auth   string
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	data, _ := io.ReadAll(req.Body)
+	var body map[string]string
+	_ = json.Unmarshal(data, &body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bodies[req.URL.Path] = body
+	if req.URL.Path == "/hook" {
+		r.auth = req.Header.Get("Authorization")
+	}
+	if req.URL.Path == "/fail" {
+		http.Error(w, "bad token", http.StatusForbidden)
+	}
+}
+
+func writeConfig(t *testing.T, cfg string) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("XDG_CACHE_HOME", dir)
+	t.Setenv("HOME", dir)
+	path, err := ConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(cfg), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSendProviders(t *testing.T) {
+	rec := &recorder{bodies: make(map[string]map[string]string)}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	t.Setenv("HOOK_TOKEN", "secret")
+	writeConfig(t, `{
+  "desktop": false,
+  "providers": [
+    {"type": "slack", "url": "`+server.URL+`/slack"},
+    {"type": "discord", "url": "`+server.URL+`/discord", "events": ["error"]},
+    {"type": "webhook", "url": "`+server.URL+`/hook", "headers": {"Authorization": "Bearer ${HOOK_TOKEN}"}}
+  ]
+}`)
+
+	err := Send(Options{Event: EventStop, Title: "Claude Code", Subtitle: "agentctl", Message: "Done", Group: "agentctl-agentctl"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if got := rec.bodies["/slack"]["text"]; got != "*Claude Code* · agentctl\nDone" {
+		t.Errorf("slack text = %q", got)
+	}
+	if _, ok := rec.bodies["/discord"]; ok {
+		t.Errorf("discord received a stop notification despite its events filter")
+	}
+	hook := rec.bodies["/hook"]
+	if hook["event"] != EventStop || hook["message"] != "Done" || hook["group"] != "agentctl-agentctl" {
+		t.Errorf("webhook body = %v", hook)
+	}
+	if rec.auth != "Bearer secret" {
+		t.Errorf("webhook Authorization = %q, want expanded token", rec.auth)
+	}
+
+	if err := Send(Options{Event: EventError, Title: "Claude Code", Message: "Boom"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if got := rec.bodies["/discord"]["content"]; got != "**Claude Code**\nBoom" {
+		t.Errorf("discord content = %q", got)
+	}
+}
+
+func TestSendReportsFailures(t *testing.T) {
+	rec := &recorder{bodies: make(map[string]map[string]string)}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	writeConfig(t, `{"desktop": false, "providers": [
+  {"type": "webhook", "url": "`+server.URL+`/fail"},
+  {"type": "slack", "url": "`+server.URL+`/slack"}
+]}`)
+
+	err := Send(Options{Title: "Claude Code", Message: "Done"})
+	if err == nil || !strings.Contains(err.Error(), "HTTP 403") {
+		t.Fatalf("Send() error = %v, want HTTP 403", err)
+	}
+	if _, ok := rec.bodies["/slack"]; !ok {
+		t.Errorf("a failing provider stopped delivery to the others")
+	}
+}
+
+func TestProvidersFor(t *testing.T) {
+	disabled := false
+	cfg := &Config{
+		Desktop:   &disabled,
+		Providers: []ProviderConfig{{Type: "pager", URL: "https://example.com"}},
+	}
+	if _, err := cfg.ProvidersFor(EventStop); err == nil {
+		t.Errorf("ProvidersFor() accepted an unknown provider type")
+	}
+
+	cfg = &Config{}
+	providers, err := cfg.ProvidersFor(EventStop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(providers) != 1 || providers[0].Name() != "desktop" {
+		t.Errorf("default providers = %v, want desktop only", providers)
+	}
+}
+
+func TestSendThrottling(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		mu.Lock()
+		received = append(received, body["message"])
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	writeConfig(t, `{
+  "desktop": false,
+  "rate_limit": 3,
+  "collapse_window": "2m",
+  "quiet_hours": {"start": "22:00", "end": "07:00", "allow": ["error"]},
+  "providers": [{"type": "webhook", "url": "`+server.URL+`"}]
+}`)
+
+	clock := time.Date(2026, 1, 5, 12, 0, 0, 0, time.Local)
+	defer func() { now = time.Now }()
+	now = func() time.Time { return clock }
+
+	send := func(event, group, message string) {
+		t.Helper()
+		if err := Send(Options{Event: event, Group: group, Title: "Claude Code", Message: message}); err != nil {
+			t.Fatalf("Send(%q) error = %v", message, err)
+		}
+	}
+
+	send(EventInput, "agentctl-a", "first")
+	send(EventInput, "agentctl-a", "duplicate")
+	send(EventStop, "agentctl-a", "other event")
+	send(EventInput, "agentctl-b", "other group")
+	send(EventError, "agentctl-c", "over limit")
+
+	clock = clock.Add(3 * time.Minute)
+	send(EventInput, "agentctl-a", "after window")
+
+	clock = time.Date(2026, 1, 5, 23, 30, 0, 0, time.Local)
+	send(EventStop, "agentctl-d", "quiet")
+	send(EventError, "agentctl-d", "allowed while quiet")
+
+	want := []string{"first", "other event", "other group", "after window", "allowed while quiet"}
+	if strings.Join(received, ",") != strings.Join(want, ",") {
+		t.Errorf("delivered %v, want %v", received, want)
+	}
+}
+
+func TestQuietHoursContains(t *testing.T) {
+	tests := []struct {
+		start, end string
+		clock      string
+		want       bool
+	}{
+		{"22:00", "07:00", "23:15", true},
+		{"22:00", "07:00", "06:59", true},
+		{"22:00", "07:00", "07:00", false},
+		{"22:00", "07:00", "12:00", false},
+		{"12:00", "13:30", "13:00", true},
+		{"12:00", "13:30", "14:00", false},
+	}
+	for _, tt := range tests {
+		cfg := &Config{QuietHours: &QuietHours{Start: tt.start, End: tt.end}}
+		if err := cfg.validate(); err != nil {
+			t.Fatal(err)
+		}
+		clock, _ := time.Parse("15:04", tt.clock)
+		if got := cfg.QuietHours.contains(clock); got != tt.want {
+			t.Errorf("quiet hours %s-%s contain %s = %v, want %v", tt.start, tt.end, tt.clock, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfigValidates(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     string
+		wantErr string
+	}{
+		{"valid", `{"rate_limit": 5, "collapse_window": "90s", "quiet_hours": {"start": "22:00", "end": "07:00"}, "providers": [{"type": "slack", "url": "${SLACK_URL}"}]}`, ""},
+		{"quiet hours start", `{"quiet_hours": {"start": "10pm", "end": "07:00"}}`, `invalid quiet hours time "10pm"`},
+		{"quiet hours end", `{"quiet_hours": {"start": "22:00"}}`, `invalid quiet hours time ""`},
+		{"collapse window", `{"collapse_window": "2 minutes"}`, "invalid collapse_window"},
+		{"rate limit", `{"rate_limit": -1}`, "invalid rate_limit"},
+		{"provider type", `{"providers": [{"type": "pager", "url": "https://example.com"}]}`, `unknown notification provider type "pager"`},
+		{"provider url", `{"providers": [{"type": "webhook"}]}`, "has no url"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeConfig(t, tt.cfg)
+			_, err := LoadConfig()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("LoadConfig() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSendThrottlingConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	delivered := 0
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		mu.Lock()
+		delivered++
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	writeConfig(t, `{"desktop": false, "rate_limit": 3, "providers": [{"type": "webhook", "url": "`+server.URL+`"}]}`)
+
+	const hooks = 12
+	var wg sync.WaitGroup
+	for i := range hooks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := Send(Options{Event: EventStop, Group: fmt.Sprintf("agentctl-%d", i), Message: "done"}); err != nil {
+				t.Errorf("Send() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if delivered != 3 {
+		t.Errorf("delivered %d notifications from %d concurrent hooks, want the rate limit of 3", delivered, hooks)
+	}
+}
+
+func TestSendFailureNotThrottled(t *testing.T) {
+	var mu sync.Mutex
+	var received []string
+	failing := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		mu.Lock()
+		defer mu.Unlock()
+		if failing {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		received = append(received, body["message"])
+	}))
+	defer server.Close()
+
+	writeConfig(t, `{"desktop": false, "rate_limit": 1, "collapse_window": "2m", "providers": [{"type": "webhook", "url": "`+server.URL+`"}]}`)
+
+	opts := Options{Event: EventStop, Group: "agentctl", Title: "Claude Code", Message: "done"}
+	if err := Send(opts); err == nil {
+		t.Fatal("Send() to a failing provider succeeded")
+	}
+
+	mu.Lock()
+	failing = false
+	mu.Unlock()
+	if err := Send(opts); err != nil {
+		t.Fatalf("Send() retry error = %v", err)
+	}
+	if len(received) != 1 {
+		t.Errorf("retry after a failed delivery was suppressed: delivered %v", received)
+	}
+
+	// The successful delivery does count
+	if err := Send(opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(received) != 1 {
+		t.Errorf("duplicate after a successful delivery was sent: delivered %v", received)
+	}
+}
@@ -0,0 +1,145 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// Provider delivers notifications to one destination.
+type Provider interface {
+	Name() string
+	Send(ctx context.Context, opts Options) error
+}
+
+// Provider types accepted in ProviderConfig.Type.
+const (
+	ProviderSlack   = "slack"
+	ProviderDiscord = "discord"
+	ProviderWebhook = "webhook"
+)
+
+// Config is the user's notification config.
+type Config struct {
+	// Desktop controls local notifications; nil means enabled. Disable it
+	// on headless machines that only use remote providers.
+	Desktop *bool `json:"desktop,omitempty"`
+	// Providers are remote destinations notified alongside the desktop.
+	Providers []ProviderConfig `json:"providers,omitempty"`
+	// RateLimit caps notifications per minute; 0 means unlimited.
+	RateLimit int `json:"rate_limit,omitempty"`
+	// CollapseWindow drops a notification when one with the same Group and
+	// Event was sent within this duration, e.g. "2m".
+	CollapseWindow string `json:"collapse_window,omitempty"`
+	// QuietHours holds back notifications during a daily window.
+	QuietHours *QuietHours `json:"quiet_hours,omitempty"`
+
+	// collapse is CollapseWindow parsed by validateThrottle.
+	collapse time.Duration
+}
+
+// ProviderConfig configures one remote provider. URL and header values may
+// reference environment variables as $VAR or ${VAR}, so secrets can stay out
+// of the file.
+type ProviderConfig struct {
+	Type    string            `json:"type"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Events limits the provider to these events; empty means all.
+	Events []string `json:"events,omitempty"`
+}
+
+// ConfigPath returns the location of the notification config.
+func ConfigPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "agentctl", "notify.json"), nil
+}
+
+// LoadConfig reads and validates the notification config. A missing file
+// yields the default: desktop notifications only.
+func LoadConfig() (*Config, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	data, err := os.ReadFile(path) //nolint:gosec // Path is in the user config directory
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// validate checks provider types and throttling settings.
+func (c *Config) validate() error {
+	for _, pc := range c.Providers {
+		switch pc.Type {
+		case ProviderSlack, ProviderDiscord, ProviderWebhook:
+		default:
+			return fmt.Errorf("unknown notification provider type %q (valid types: %s, %s, %s)", pc.Type, ProviderSlack, ProviderDiscord, ProviderWebhook)
+		}
+		if pc.URL == "" {
+			return fmt.Errorf("%s notification provider has no url", pc.Type)
+		}
+	}
+	return c.validateThrottle()
+}
+
+// ProvidersFor returns the providers that should receive a notification for
+// event. An empty event goes to every provider.
+func (c *Config) ProvidersFor(event string) ([]Provider, error) {
+	var providers []Provider
+	if c.Desktop == nil || *c.Desktop {
+		providers = append(providers, Desktop{})
+	}
+	for _, pc := range c.Providers {
+		if event != "" && len(pc.Events) > 0 && !slices.Contains(pc.Events, event) {
+			continue
+		}
+		provider, err := pc.provider()
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+	return providers, nil
+}
+
+func (pc ProviderConfig) provider() (Provider, error) {
+	url := os.ExpandEnv(pc.URL)
+	if url == "" {
+		return nil, fmt.Errorf("%s notification provider has no url", pc.Type)
+	}
+	client := &http.Client{Timeout: sendTimeout}
+
+	switch pc.Type {
+	case ProviderSlack:
+		return &Slack{URL: url, Client: client}, nil
+	case ProviderDiscord:
+		return &Discord{URL: url, Client: client}, nil
+	case ProviderWebhook:
+		headers := make(map[string]string, len(pc.Headers))
+		for key, value := range pc.Headers {
+			headers[key] = os.ExpandEnv(value)
+		}
+		return &Webhook{URL: url, Headers: headers, Client: client}, nil
+	}
+	return nil, fmt.Errorf("unknown notification provider type %q (valid types: %s, %s, %s)", pc.Type, ProviderSlack, ProviderDiscord, ProviderWebhook)
+}
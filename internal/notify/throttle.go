@@ -0,0 +1,220 @@
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/fsutil"
+)
+
+// rateWindow is the period RateLimit counts notifications over.
+const rateWindow = time.Minute
+
+// stateLockTimeout bounds how long a hook waits for another one to finish
+// updating the throttle state.
+const stateLockTimeout = time.Second
+
+// now is the clock used for throttling; a variable so tests can fix it.
+var now = time.Now
+
+// QuietHours is a daily window, in local time, when notifications are held
+// back. Start and End are "HH:MM"; a window may cross midnight.
+type QuietHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Allow lists events still delivered during quiet hours, e.g. "error".
+	Allow []string `json:"allow,omitempty"`
+
+	// start and end are Start and End as minutes past midnight, set by
+	// validateThrottle.
+	start, end int
+}
+
+// sentRecord is one delivered notification in the throttle state.
+type sentRecord struct {
+	Time  time.Time `json:"time"`
+	Event string    `json:"event,omitempty"`
+	Group string    `json:"group,omitempty"`
+}
+
+// throttleState persists recent deliveries across hook processes.
+type throttleState struct {
+	Sent []sentRecord `json:"sent"`
+}
+
+// suppressReason returns why opts should not be sent now, or "" to send it.
+// The config must have been validated by LoadConfig. Hooks run as separate
+// processes, so recent deliveries are read from and recorded to a state file
+// under a lock; on any state error the notification is sent.
+//
+// A notification that may be sent is recorded right away, so concurrent hooks
+// see it. If no provider then delivers it, the caller must call the returned
+// forget function so the failed attempt doesn't count toward the rate limit
+// or suppress a retry as a duplicate. forget is nil when nothing was recorded.
+func (c *Config) suppressReason(opts Options) (string, func() error, error) {
+	t := now()
+	if c.QuietHours != nil && !slices.Contains(c.QuietHours.Allow, opts.Event) && c.QuietHours.contains(t) {
+		return "quiet hours", nil, nil
+	}
+	if c.RateLimit <= 0 && c.collapse <= 0 {
+		return "", nil, nil
+	}
+
+	path, unlock, err := lockThrottleState()
+	if err != nil {
+		return "", nil, err
+	}
+	defer unlock()
+
+	state, err := loadThrottleState(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	keep := max(rateWindow, c.collapse)
+	recent := state.Sent[:0]
+	inWindow := 0
+	for _, sent := range state.Sent {
+		age := t.Sub(sent.Time)
+		if age >= keep {
+			continue
+		}
+		recent = append(recent, sent)
+		if age < rateWindow {
+			inWindow++
+		}
+		if age < c.collapse && opts.Group != "" && sent.Group == opts.Group && sent.Event == opts.Event {
+			return "duplicate of a recent notification", nil, nil
+		}
+	}
+	if c.RateLimit > 0 && inWindow >= c.RateLimit {
+		return fmt.Sprintf("rate limit of %d per minute", c.RateLimit), nil, nil
+	}
+
+	record := sentRecord{Time: t, Event: opts.Event, Group: opts.Group}
+	state.Sent = append(recent, record)
+	if err := saveThrottleState(path, state); err != nil {
+		return "", nil, err
+	}
+	return "", func() error { return forgetSent(record) }, nil
+}
+
+// forgetSent removes a delivery recorded by suppressReason that never
+// reached any provider.
+func forgetSent(record sentRecord) error {
+	path, unlock, err := lockThrottleState()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := loadThrottleState(path)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(state.Sent, func(sent sentRecord) bool {
+		return sent.Time.Equal(record.Time) && sent.Event == record.Event && sent.Group == record.Group
+	})
+	if i < 0 {
+		return nil
+	}
+	state.Sent = slices.Delete(state.Sent, i, i+1)
+	return saveThrottleState(path, state)
+}
+
+// lockThrottleState locks the throttle state directory and returns the state
+// file path with the function that releases the lock.
+func lockThrottleState() (string, func(), error) {
+	path, err := throttleStatePath()
+	if err != nil {
+		return "", nil, err
+	}
+	dir := filepath.Dir(path)
+	if err := fsutil.MkdirAll(dir); err != nil {
+		return "", nil, err
+	}
+	unlock, err := fsutil.LockWait(dir, stateLockTimeout)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, unlock, nil
+}
+
+// validateThrottle checks the throttling settings and parses them for
+// suppressReason, so a typo is reported when the config is loaded rather
+// than on every notification.
+func (c *Config) validateThrottle() error {
+	if c.RateLimit < 0 {
+		return fmt.Errorf("invalid rate_limit %d: must not be negative", c.RateLimit)
+	}
+	if c.CollapseWindow != "" {
+		window, err := time.ParseDuration(c.CollapseWindow)
+		if err != nil {
+			return fmt.Errorf("invalid collapse_window %q: %w", c.CollapseWindow, err)
+		}
+		c.collapse = window
+	}
+	if c.QuietHours != nil {
+		var err error
+		if c.QuietHours.start, err = minuteOfDay(c.QuietHours.Start); err != nil {
+			return err
+		}
+		if c.QuietHours.end, err = minuteOfDay(c.QuietHours.End); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// contains reports whether t falls inside the quiet hours.
+func (q *QuietHours) contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	if q.start <= q.end {
+		return minute >= q.start && minute < q.end
+	}
+	return minute >= q.start || minute < q.end
+}
+
+func minuteOfDay(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quiet hours time %q: expected HH:MM", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func throttleStatePath() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "agentctl", "notify-state.json"), nil
+}
+
+func loadThrottleState(path string) (*throttleState, error) {
+	state := &throttleState{}
+	data, err := os.ReadFile(path) //nolint:gosec // Path is in the user cache directory
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		// A corrupt state file only loses throttling history
+		return &throttleState{}, nil
+	}
+	return state, nil
+}
+
+func saveThrottleState(path string, state *throttleState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFile(path, append(data, '\n'), fsutil.FileMode())
+}
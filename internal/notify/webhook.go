@@ -0,0 +1,129 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/trace"
+)
+
+// Slack posts notifications to a Slack incoming webhook.
+type Slack struct {
+	URL    string
+	Client *http.Client
+}
+
+// Name implements Provider.
+func (s *Slack) Name() string {
+	return ProviderSlack
+}
+
+// Send implements Provider.
+func (s *Slack) Send(ctx context.Context, opts Options) error {
+	text := "*" + opts.Title + "*"
+	if opts.Subtitle != "" {
+		text += " · " + opts.Subtitle
+	}
+	text += "\n" + opts.Message
+	return postJSON(ctx, s.Client, s.URL, nil, map[string]string{"text": text})
+}
+
+// Discord posts notifications to a Discord channel webhook.
+type Discord struct {
+	URL    string
+	Client *http.Client
+}
+
+// Name implements Provider.
+func (d *Discord) Name() string {
+	return ProviderDiscord
+}
+
+// Send implements Provider.
+func (d *Discord) Send(ctx context.Context, opts Options) error {
+	content := "**" + opts.Title + "**"
+	if opts.Subtitle != "" {
+		content += " · " + opts.Subtitle
+	}
+	content += "\n" + opts.Message
+	return postJSON(ctx, d.Client, d.URL, nil, map[string]string{
+		"username": "agentctl",
+		"content":  content,
+	})
+}
+
+// Webhook posts notifications as JSON to any HTTP endpoint.
+type Webhook struct {
+	URL     string
+	Headers map[string]string
+	Client  *http.Client
+}
+
+// WebhookPayload is the JSON body sent by Webhook.
+type WebhookPayload struct {
+	Event    string `json:"event,omitempty"`
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+	Message  string `json:"message"`
+	Group    string `json:"group,omitempty"`
+}
+
+// Name implements Provider.
+func (w *Webhook) Name() string {
+	return ProviderWebhook
+}
+
+// Send implements Provider.
+func (w *Webhook) Send(ctx context.Context, opts Options) error {
+	return postJSON(ctx, w.Client, w.URL, w.Headers, WebhookPayload{
+		Event:    opts.Event,
+		Title:    opts.Title,
+		Subtitle: opts.Subtitle,
+		Message:  opts.Message,
+		Group:    opts.Group,
+	})
+}
+
+// postJSON posts body as JSON to endpoint and fails on a non-2xx response.
+func postJSON(ctx context.Context, client *http.Client, endpoint string, headers map[string]string, body interface{}) (err error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "agentctl")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	span := trace.StartSpan(trace.KindHTTP, "notify "+req.URL.Host)
+	defer func() { span.End(err) }()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// Webhook URLs embed their secret, so report only the host
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			return fmt.Errorf("post to %s: %w", req.URL.Host, urlErr.Err)
+		}
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s returned HTTP %d: %s", req.URL.Host, resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
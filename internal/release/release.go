@@ -0,0 +1,235 @@
+// Package release checks GitHub for newer agentctl releases and replaces the
+// running binary with a verified download.
+package release
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/fsutil"
+)
+
+// Repo is the GitHub repository agentctl is released from.
+const Repo = "ryantking/agentctl"
+
+// apiBase is the GitHub API root; a variable so tests can point it elsewhere.
+var apiBase = "https://api.github.com"
+
+// Asset is a file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Release is a published GitHub release.
+type Release struct {
+	Tag    string  `json:"tag_name"`
+	URL    string  `json:"html_url"`
+	Assets []Asset `json:"assets"`
+}
+
+// Version returns the release version without a leading "v".
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.Tag, "v")
+}
+
+// asset returns the named asset, or nil if the release lacks it.
+func (r *Release) asset(name string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// Latest returns the most recent published release.
+// GITHUB_TOKEN is used when set to avoid anonymous rate limits.
+func Latest(ctx context.Context) (*Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiBase+"/repos/"+Repo+"/releases/latest", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query releases: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to query releases: GitHub returned HTTP %d", resp.StatusCode)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("invalid release response: %w", err)
+	}
+	return &release, nil
+}
+
+// IsNewer reports whether latest is a higher semantic version than current.
+// Development builds ("dev" or unparseable versions) are never considered
+// outdated, since there is nothing meaningful to compare.
+func IsNewer(current, latest string) bool {
+	cur, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	lat, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	for i := range cur {
+		if lat[i] != cur[i] {
+			return lat[i] > cur[i]
+		}
+	}
+	return false
+}
+
+// parseVersion parses "v1.2.3" or "1.2.3", ignoring pre-release and build suffixes.
+func parseVersion(v string) ([3]int, bool) {
+	var parts [3]int
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	fields := strings.Split(v, ".")
+	if len(fields) != 3 {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+// ArchiveName returns the release archive for this platform, matching the
+// goreleaser name template.
+func ArchiveName(version string) string {
+	return fmt.Sprintf("agentctl_%s_%s_%s.tar.gz", strings.TrimPrefix(version, "v"), runtime.GOOS, runtime.GOARCH)
+}
+
+// Install downloads the release archive for this platform, verifies it
+// against the release's checksums.txt, and atomically replaces exePath with
+// the agentctl binary it contains.
+func Install(ctx context.Context, release *Release, exePath string) error {
+	archiveName := ArchiveName(release.Tag)
+	archive := release.asset(archiveName)
+	if archive == nil {
+		return fmt.Errorf("release %s has no build for %s/%s", release.Tag, runtime.GOOS, runtime.GOARCH)
+	}
+	checksums := release.asset("checksums.txt")
+	if checksums == nil {
+		return fmt.Errorf("release %s has no checksums.txt; refusing to install unverified binary", release.Tag)
+	}
+
+	sums, err := download(ctx, checksums.URL)
+	if err != nil {
+		return err
+	}
+	want, err := findChecksum(sums, archiveName)
+	if err != nil {
+		return err
+	}
+
+	data, err := download(ctx, archive.URL)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", archiveName, want, got)
+	}
+
+	binary, err := extractBinary(data, "agentctl")
+	if err != nil {
+		return err
+	}
+	return replaceFile(exePath, binary)
+}
+
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: HTTP %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// findChecksum returns the SHA-256 for name from a sha256sum-format file.
+func findChecksum(sums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(strings.NewReader(string(sums)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == name {
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("checksums.txt has no entry for %s", name)
+}
+
+// extractBinary returns the contents of the named file from a .tar.gz archive.
+func extractBinary(archive []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(strings.NewReader(string(archive)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid release archive: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("release archive does not contain %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid release archive: %w", err)
+		}
+		if header.Typeflag == tar.TypeReg && filepath.Base(header.Name) == name {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// replaceFile atomically swaps the binary at path for data, keeping its mode,
+// so the running binary is never left half-written.
+func replaceFile(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err := fsutil.WriteFile(path, data, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("cannot write to %s: %w", filepath.Dir(path), err)
+	}
+	return nil
+}
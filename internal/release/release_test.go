@@ -0,0 +1,112 @@
+package release
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		want            bool
+	}{
+		{"1.2.3", "v1.2.4", true},
+		{"v1.2.3", "1.10.0", true},
+		{"1.2.3", "1.2.3", false},
+		{"2.0.0", "1.9.9", false},
+		{"1.2.3-rc1", "1.2.3", false},
+		{"dev", "1.0.0", false},
+	}
+	for _, tt := range tests {
+		if got := IsNewer(tt.current, tt.latest); got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+		}
+	}
+}
+
+func TestInstall(t *testing.T) {
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	binary := []byte("#!/bin/sh\necho new\n")
+	if err := tw.WriteHeader(&tar.Header{Name: "agentctl", Mode: 0755, Size: int64(len(binary)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(binary); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	name := ArchiveName("v1.2.3")
+	sum := sha256.Sum256(archive.Bytes())
+	checksums := fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), name)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + name:
+			_, _ = w.Write(archive.Bytes())
+		case "/checksums.txt":
+			_, _ = w.Write([]byte(checksums))
+		case "/repos/" + Repo + "/releases/latest":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"tag_name": "v1.2.3"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apiBase = server.URL
+	latest, err := Latest(context.Background())
+	if err != nil || latest.Version() != "1.2.3" {
+		t.Fatalf("Latest() = %v, %v; want v1.2.3", latest, err)
+	}
+
+	release := &Release{Tag: "v1.2.3", Assets: []Asset{
+		{Name: name, URL: server.URL + "/" + name},
+		{Name: "checksums.txt", URL: server.URL + "/checksums.txt"},
+	}}
+
+	exePath := filepath.Join(t.TempDir(), "agentctl")
+	if err := os.WriteFile(exePath, []byte("old"), 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(exePath, 0750); err != nil {
+		t.Fatal(err)
+	}
+	if err := Install(context.Background(), release, exePath); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	got, err := os.ReadFile(exePath)
+	if err != nil || !bytes.Equal(got, binary) {
+		t.Errorf("binary = %q, %v; want new binary", got, err)
+	}
+	info, err := os.Stat(exePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Mode().Perm(); got != 0750 {
+		t.Errorf("binary mode = %v, want the previous mode 0750", got)
+	}
+
+	// A tampered checksum must be rejected
+	checksums = fmt.Sprintf("%064d  %s\n", 0, name)
+	if err := Install(context.Background(), release, exePath); err == nil {
+		t.Error("Install() accepted an archive with a mismatched checksum")
+	}
+}
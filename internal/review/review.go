@@ -0,0 +1,115 @@
+// Package review defines the structured code review the agent is asked to
+// produce and parses it from the agent's response.
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity levels, from most to least serious.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// severityRank orders severities; higher is more serious.
+var severityRank = map[string]int{
+	SeverityInfo:    1,
+	SeverityWarning: 2,
+	SeverityError:   3,
+}
+
+// Issue is a single review finding.
+type Issue struct {
+	File       string `json:"file"`
+	Line       int    `json:"line,omitempty"`
+	Severity   string `json:"severity"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// Location returns the issue's file:line, or just the file when the line is unknown.
+func (i Issue) Location() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("%s:%d", i.File, i.Line)
+	}
+	return i.File
+}
+
+// Review is the agent's assessment of a diff.
+type Review struct {
+	Summary string  `json:"summary"`
+	Issues  []Issue `json:"issues"`
+}
+
+// Schema describes the JSON the agent must return, for inclusion in prompts.
+const Schema = `{
+  "summary": "one-paragraph overall assessment",
+  "issues": [
+    {
+      "file": "path relative to the repository root",
+      "line": 42,
+      "severity": "error | warning | info",
+      "message": "what is wrong and why it matters",
+      "suggestion": "concrete fix, or empty"
+    }
+  ]
+}`
+
+// ValidSeverity reports whether s is a known severity.
+func ValidSeverity(s string) bool {
+	return severityRank[s] > 0
+}
+
+// AtLeast reports whether severity is as serious as threshold.
+func AtLeast(severity, threshold string) bool {
+	return severityRank[severity] >= severityRank[threshold]
+}
+
+// Parse extracts the review JSON from an agent response, tolerating code
+// fences or prose around it. Issues are normalized and sorted by file and line.
+func Parse(response string) (*Review, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start == -1 || end < start {
+		return nil, fmt.Errorf("agent response contains no review JSON")
+	}
+
+	var r Review
+	if err := json.Unmarshal([]byte(response[start:end+1]), &r); err != nil {
+		return nil, fmt.Errorf("failed to parse review from agent response: %w", err)
+	}
+
+	for i := range r.Issues {
+		severity := strings.ToLower(strings.TrimSpace(r.Issues[i].Severity))
+		if !ValidSeverity(severity) {
+			severity = SeverityInfo
+		}
+		r.Issues[i].Severity = severity
+	}
+	sort.SliceStable(r.Issues, func(i, j int) bool {
+		if r.Issues[i].File != r.Issues[j].File {
+			return r.Issues[i].File < r.Issues[j].File
+		}
+		return r.Issues[i].Line < r.Issues[j].Line
+	})
+	if r.Issues == nil {
+		r.Issues = []Issue{}
+	}
+	return &r, nil
+}
+
+// Count returns how many issues are at least as serious as threshold.
+func (r *Review) Count(threshold string) int {
+	n := 0
+	for _, issue := range r.Issues {
+		if AtLeast(issue.Severity, threshold) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,37 @@
+package review
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	response := "Here is my review:\n```json\n" + `{
+  "summary": "Mostly fine.",
+  "issues": [
+    {"file": "b.go", "line": 3, "severity": "Warning", "message": "unchecked error"},
+    {"file": "a.go", "line": 10, "severity": "error", "message": "nil dereference", "suggestion": "check for nil"},
+    {"file": "a.go", "line": 2, "severity": "nitpick", "message": "naming"}
+  ]
+}` + "\n```\n"
+
+	r, err := Parse(response)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if r.Summary != "Mostly fine." || len(r.Issues) != 3 {
+		t.Fatalf("Parse() = %+v", r)
+	}
+
+	want := []string{"a.go:2 info", "a.go:10 error", "b.go:3 warning"}
+	for i, issue := range r.Issues {
+		if got := issue.Location() + " " + issue.Severity; got != want[i] {
+			t.Errorf("issue %d = %q, want %q", i, got, want[i])
+		}
+	}
+
+	if n := r.Count(SeverityWarning); n != 2 {
+		t.Errorf("Count(warning) = %d, want 2", n)
+	}
+
+	if _, err := Parse("no issues found"); err == nil {
+		t.Error("Parse() accepted a response without JSON")
+	}
+}
@@ -0,0 +1,162 @@
+package setup
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/fsutil"
+)
+
+// backupDir holds, under .claude, one directory per backed-up run with the
+// previous version of every file that run overwrote.
+const backupDir = ".agentctl-backups"
+
+// BackupTimeFormat names backup directories; it sorts chronologically.
+const BackupTimeFormat = "20060102T150405Z"
+
+// SetBackup enables saving the previous version of every file this manager
+// overwrites, so the run can be rolled back with Restore.
+func (m *Manager) SetBackup(enabled bool) {
+	m.backupID = ""
+	if enabled {
+		m.backupID = time.Now().UTC().Format(BackupTimeFormat)
+	}
+}
+
+// Backup copies the current contents of path into this run's backup
+// directory. It does nothing when backups are disabled, path does not exist,
+// or the file was already backed up by this run.
+func (m *Manager) Backup(path string) error {
+	if m.backupID == "" {
+		return nil
+	}
+	relPath, err := filepath.Rel(m.target, path)
+	if err != nil {
+		return err
+	}
+	dest := filepath.Join(m.backupRoot(), m.backupID, relPath)
+	if _, err := os.Stat(dest); err == nil {
+		return nil
+	}
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // Path is inside the install target
+	if err != nil {
+		return err
+	}
+	if err := fsutil.MkdirAll(filepath.Dir(dest)); err != nil {
+		return err
+	}
+	if err := fsutil.WriteFile(dest, data, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	// Set before ignoring: updating .gitignore backs it up through here
+	if !m.backupsIgnored {
+		m.backupsIgnored = true
+		if _, err := m.ensureGitignored(".claude/" + backupDir + "/"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile backs up destPath if enabled and data changes it, then replaces
+// it with data.
+func (m *Manager) writeFile(destPath string, data []byte) error {
+	if existing, err := os.ReadFile(destPath); err != nil || !bytes.Equal(existing, data) { //nolint:gosec // Path is inside the install target
+		if err := m.Backup(destPath); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", destPath, err)
+		}
+	}
+	return fsutil.WriteFile(destPath, data, fsutil.FileMode())
+}
+
+// Backups lists the IDs of the target's backups, oldest first.
+func (m *Manager) Backups() ([]string, error) {
+	entries, err := os.ReadDir(m.backupRoot())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			ids = append(ids, entry.Name())
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Restore copies every file in backup id back into the target and returns
+// their paths relative to the target. With dryRun, only lists them.
+// The id must be one listed by Backups, so it can't name a directory outside
+// the backup root.
+func (m *Manager) Restore(id string, dryRun bool) ([]string, error) {
+	backups, err := m.Backups()
+	if err != nil {
+		return nil, err
+	}
+	if strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") || !slices.Contains(backups, id) {
+		return nil, fmt.Errorf("no backup %q in %s", id, m.backupRoot())
+	}
+	root := filepath.Join(m.backupRoot(), id)
+
+	if !dryRun {
+		unlock, err := m.Lock()
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+
+	var restored []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		restored = append(restored, relPath)
+		if dryRun {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path) //nolint:gosec // Path is inside the backup directory
+		if err != nil {
+			return err
+		}
+		destPath := filepath.Join(m.target, relPath)
+		if err := fsutil.MkdirAll(filepath.Dir(destPath)); err != nil {
+			return err
+		}
+		return fsutil.WriteFile(destPath, data, info.Mode().Perm())
+	})
+	return restored, err
+}
+
+func (m *Manager) backupRoot() string {
+	return filepath.Join(m.target, ".claude", backupDir)
+}
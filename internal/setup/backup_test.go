@@ -0,0 +1,71 @@
+package setup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRestore(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "repo")
+	claudeMD := filepath.Join(target, "CLAUDE.md")
+	if err := os.MkdirAll(target, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(claudeMD, []byte("mine\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewManager(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetBackup(true)
+	if err := m.writeFile(claudeMD, []byte("template\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := m.Backups()
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("Backups() = %v, %v; want one backup", backups, err)
+	}
+	restored, err := m.Restore(backups[0], false)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if len(restored) != 1 || restored[0] != "CLAUDE.md" {
+		t.Errorf("Restore() = %v, want [CLAUDE.md]", restored)
+	}
+	if data, _ := os.ReadFile(claudeMD); string(data) != "mine\n" {
+		t.Errorf("CLAUDE.md = %q after restore, want the backed-up version", data)
+	}
+}
+
+func TestRestoreRejectsIDsOutsideBackups(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "repo")
+	if err := os.MkdirAll(filepath.Join(target, ".claude", backupDir, "20260101T000000Z"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// A directory an id like ../../../outside would reach
+	if err := os.MkdirAll(filepath.Join(dir, "outside"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "outside", "planted.md"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewManager(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"../../../outside", "..", "20260101T000000Z/..", "20260101T000000Z/", "missing", ""} {
+		if _, err := m.Restore(id, false); err == nil {
+			t.Errorf("Restore(%q) succeeded", id)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(target, "planted.md")); !os.IsNotExist(err) {
+		t.Errorf("restore copied a file from outside the backup directory")
+	}
+}
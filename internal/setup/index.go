@@ -0,0 +1,171 @@
+package setup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/agent"
+	"github.com/ryantking/agentctl/internal/fsutil"
+)
+
+const (
+	indexStartMarker   = "<!-- REPOSITORY_INDEX_START -->"
+	indexEndMarker     = "<!-- REPOSITORY_INDEX_END -->"
+	indexUpdatedPrefix = "<!-- REPOSITORY_INDEX_UPDATED: "
+	indexUpdatedSuffix = " -->"
+)
+
+// ErrIndexMarkersNotFound indicates a memory file has no repository index block.
+var ErrIndexMarkersNotFound = errors.New("repository index markers not found")
+
+// MemoryFiles are the memory files, relative to the repository root, that
+// may hold a repository index block.
+var MemoryFiles = []string{"CLAUDE.md", "AGENTS.md"}
+
+// RepositoryIndex is the generated block between the REPOSITORY_INDEX markers
+// of a memory file.
+type RepositoryIndex struct {
+	Path    string    `json:"path"`
+	Content string    `json:"content"`
+	Updated time.Time `json:"updated,omitempty"`
+}
+
+// ReadRepositoryIndex returns the index block of the memory file at path.
+// Updated is zero when the block predates update timestamps.
+func ReadRepositoryIndex(path string) (*RepositoryIndex, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is a memory file in the repository
+	if err != nil {
+		return nil, err
+	}
+
+	content := string(data)
+	startIdx := strings.Index(content, indexStartMarker)
+	endIdx := strings.Index(content, indexEndMarker)
+	if startIdx == -1 || endIdx == -1 || endIdx < startIdx {
+		return nil, ErrIndexMarkersNotFound
+	}
+
+	index := &RepositoryIndex{Path: path}
+	block := strings.TrimSpace(content[startIdx+len(indexStartMarker) : endIdx])
+	if strings.HasPrefix(block, indexUpdatedPrefix) {
+		line, rest, _ := strings.Cut(block, "\n")
+		stamp := strings.TrimSuffix(strings.TrimPrefix(line, indexUpdatedPrefix), indexUpdatedSuffix)
+		if updated, err := time.Parse(time.RFC3339, stamp); err == nil {
+			index.Updated = updated
+		}
+		block = strings.TrimSpace(rest)
+	}
+	index.Content = block
+	return index, nil
+}
+
+// WriteRepositoryIndex replaces the index block of the memory file at path,
+// stamping it with the update time.
+func WriteRepositoryIndex(path, indexContent string, updated time.Time) error {
+	data, err := os.ReadFile(path) //nolint:gosec // Path is a memory file in the repository
+	if err != nil {
+		return err
+	}
+
+	content := string(data)
+	startIdx := strings.Index(content, indexStartMarker)
+	endIdx := strings.Index(content, indexEndMarker)
+	if startIdx == -1 || endIdx == -1 || endIdx < startIdx {
+		return ErrIndexMarkersNotFound
+	}
+
+	stamp := indexUpdatedPrefix + updated.UTC().Format(time.RFC3339) + indexUpdatedSuffix
+	updatedContent := content[:startIdx+len(indexStartMarker)] + "\n" + stamp + "\n" + indexContent + "\n" + content[endIdx:]
+
+	return fsutil.WriteFile(path, []byte(updatedContent), fsutil.FileMode())
+}
+
+// GenerateIndex asks the Claude CLI to summarize the repository and returns
+// the markdown to place between the index markers.
+func (m *Manager) GenerateIndex() (string, error) {
+	prompt := `Analyze this repository and provide a concise overview:
+- Main purpose and key technologies
+- Directory structure (2-3 levels max)
+- Entry points and main files
+- Build/run commands (check for package.json scripts, Makefile targets, Justfile recipes, etc.)
+- Available scripts and automation tools
+
+Format as clean markdown starting at heading level 3 (###), keep it brief (under 500 words).`
+
+	return agent.Run(context.Background(), agent.Request{
+		Name:   "index repository",
+		Prompt: prompt,
+		Model:  m.model,
+		Dir:    m.target,
+	})
+}
+
+// UpdateRepositoryIndex backs up the memory file at path and replaces its
+// index block. When agentctl installed the file, the manifest is updated too,
+// so uninstall and upgrade don't mistake the new index for a user edit.
+func (m *Manager) UpdateRepositoryIndex(path, content string, updated time.Time) error {
+	if m.manifest == nil {
+		manifest, err := m.loadManifest()
+		if err != nil {
+			return err
+		}
+		m.manifest = manifest
+	}
+	tracked, err := m.writeRepositoryIndex(path, content, updated)
+	if err != nil || !tracked {
+		return err
+	}
+	return m.saveManifest()
+}
+
+// writeRepositoryIndex backs up and rewrites the index block of the memory
+// file at path, refreshing its recorded hash. It reports whether the file is
+// tracked in the manifest.
+func (m *Manager) writeRepositoryIndex(path, content string, updated time.Time) (bool, error) {
+	if err := m.Backup(path); err != nil {
+		return false, err
+	}
+	if err := WriteRepositoryIndex(path, content, updated); err != nil {
+		return false, err
+	}
+
+	relPath, err := filepath.Rel(m.target, path)
+	if err != nil || m.manifest == nil || m.manifest.Files[relPath] == "" {
+		return false, nil
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // Path was just written
+	if err != nil {
+		return false, err
+	}
+	m.recordFile(path, data)
+	return true, nil
+}
+
+func (m *Manager) indexRepository() error {
+	if !agent.Installed() {
+		return agent.ErrNotInstalled
+	}
+	claudeMDPath := filepath.Join(m.target, "CLAUDE.md")
+	if _, err := os.Stat(claudeMDPath); os.IsNotExist(err) {
+		return fmt.Errorf("CLAUDE.md not found")
+	}
+
+	fmt.Print("  → Indexing repository with Claude CLI...")
+
+	indexContent, err := m.GenerateIndex()
+	if err != nil {
+		return err
+	}
+
+	if _, err := m.writeRepositoryIndex(claudeMDPath, indexContent, time.Now()); err != nil {
+		return err
+	}
+
+	fmt.Println(" done")
+	return nil
+}
@@ -0,0 +1,97 @@
+package setup
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateIndex(t *testing.T) {
+	bin := t.TempDir()
+	record := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"printf '%s\\n' \"$@\" > \"" + record + "/args\"\n" +
+		"pwd > \"" + record + "/dir\"\n" +
+		"printf '### Overview\\n\\nA CLI.\\n\\n'\n"
+	if err := os.WriteFile(filepath.Join(bin, "claude"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	target, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewManager(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetModel("haiku")
+
+	got, err := m.GenerateIndex()
+	if err != nil {
+		t.Fatalf("GenerateIndex() error = %v", err)
+	}
+	if got != "### Overview\n\nA CLI." {
+		t.Errorf("GenerateIndex() = %q", got)
+	}
+
+	args, err := os.ReadFile(filepath.Join(record, "args"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(args), "--model\nhaiku\n") || !strings.Contains(string(args), "Analyze this repository") {
+		t.Errorf("claude args = %q, want the model and the index prompt", args)
+	}
+	dir, err := os.ReadFile(filepath.Join(record, "dir"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(string(dir)) != target {
+		t.Errorf("claude ran in %q, want the target %q", strings.TrimSpace(string(dir)), target)
+	}
+}
+
+func TestUpdateRepositoryIndexThenUninstall(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	target := t.TempDir()
+	m, err := NewManager(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Install(false, true); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	// AGENTS.md is the user's own file, so uninstall must leave it
+	agentsMD := filepath.Join(target, "AGENTS.md")
+	if err := os.WriteFile(agentsMD, []byte("<!-- REPOSITORY_INDEX_START -->\n<!-- REPOSITORY_INDEX_END -->\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A fresh manager, as memory update uses
+	m, err = NewManager(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range MemoryFiles {
+		if err := m.UpdateRepositoryIndex(filepath.Join(target, name), "### Overview", time.Now()); err != nil {
+			t.Fatalf("UpdateRepositoryIndex(%s) error = %v", name, err)
+		}
+	}
+
+	m, err = NewManager(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Uninstall(false, false); err != nil {
+		t.Fatalf("Uninstall() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(target, "CLAUDE.md")); !os.IsNotExist(err) {
+		t.Errorf("CLAUDE.md kept after uninstall; the index update was treated as a user edit")
+	}
+	if _, err := os.Stat(agentsMD); err != nil {
+		t.Errorf("uninstall removed the user's AGENTS.md: %v", err)
+	}
+}
@@ -2,15 +2,16 @@
 package setup
 
 import (
-	"context"
 	"fmt"
 	"os"
-	"os/exec"
+	"path"
 	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/fsutil"
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/mcp"
 	"github.com/ryantking/agentctl/internal/templates"
 )
 
@@ -18,6 +19,14 @@ import (
 type Manager struct {
 	target      string
 	templateDir string
+	model       string
+	profile     *templates.Profile
+	manifest    *Manifest
+	backupID    string
+	// stateIgnored and backupsIgnored note that .gitignore was already
+	// checked for agentctl's own files during this run.
+	stateIgnored   bool
+	backupsIgnored bool
 }
 
 // NewManager creates a new initialization manager.
@@ -28,8 +37,46 @@ func NewManager(target string) (*Manager, error) {
 	}, nil
 }
 
+// SetModel sets the model passed to the Claude CLI when indexing the repository.
+// An empty model leaves the choice to the Claude CLI's own default.
+func (m *Manager) SetModel(model string) {
+	m.model = model
+}
+
+// SetProfile limits installation to the agents, skills, and MCP servers
+// selected by profile. A nil profile installs everything.
+func (m *Manager) SetProfile(profile *templates.Profile) {
+	m.profile = profile
+}
+
+// Lock keeps other agentctl processes from writing to the target until the
+// returned function is called. It fails with fsutil.ErrLocked if another
+// process already holds the lock.
+func (m *Manager) Lock() (func(), error) {
+	return fsutil.Lock(m.target)
+}
+
 // Install executes full initialization.
-func (m *Manager) Install(force, skipIndex bool) error {
+// Everything installed is recorded in the manifest used by Uninstall.
+func (m *Manager) Install(force, skipIndex bool) (err error) {
+	unlock, err := m.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if m.manifest, err = m.loadManifest(); err != nil {
+		return err
+	}
+	if m.profile != nil {
+		m.manifest.Profile = m.profile.Name
+	}
+	defer func() {
+		if saveErr := m.saveManifest(); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}()
+
 	// 1. Install CLAUDE.md
 	fmt.Println("Installing CLAUDE.md...")
 	if err := m.installFile("CLAUDE.md", filepath.Join(m.target, "CLAUDE.md"), force); err != nil {
@@ -58,13 +105,21 @@ func (m *Manager) Install(force, skipIndex bool) error {
 		return err
 	}
 
-	// 5. Configure MCP servers
+	// 5. Create local settings layer (repositories only)
+	if _, err := git.GetRepoRootFromPath(m.target); err == nil {
+		fmt.Println("Creating settings.local.json...")
+		if err := m.installLocalSettings(); err != nil {
+			return err
+		}
+	}
+
+	// 6. Configure MCP servers
 	fmt.Println("Configuring MCP servers...")
 	if err := m.configureMCP(force); err != nil {
 		return err
 	}
 
-	// 6. Index repository with claude CLI
+	// 7. Index repository with claude CLI
 	if !skipIndex {
 		if err := m.indexRepository(); err != nil {
 			// Non-fatal error
@@ -88,11 +143,14 @@ func (m *Manager) installFile(templatePath, destPath string, force bool) error {
 		return fmt.Errorf("failed to read template %s: %w", templatePath, err)
 	}
 
-	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil { //nolint:gosec // Template directories need to be readable
+	if err := fsutil.MkdirAll(filepath.Dir(destPath)); err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(destPath, data, 0644); err != nil { //nolint:gosec // Template files need to be readable
+	if err := m.writeFile(destPath, data); err != nil {
+		return err
+	}
+	if err := m.recordTemplate(destPath, templatePath, data); err != nil {
 		return err
 	}
 
@@ -110,13 +168,13 @@ func (m *Manager) installDirectory(templateDir, destDir string, force, recursive
 
 	if recursive {
 		// Copy entire directory trees (for skills)
-		entries, err := templates.FS.ReadDir("templates/" + templateDir)
+		entries, err := templates.ReadDirEntries(templateDir)
 		if err != nil {
 			return 0, err
 		}
 
 		for _, entry := range entries {
-			if !entry.IsDir() {
+			if !entry.IsDir() || !m.includes(templateDir, entry.Name()) {
 				continue
 			}
 
@@ -132,7 +190,7 @@ func (m *Manager) installDirectory(templateDir, destDir string, force, recursive
 			}
 
 			if err := m.copyTree(
-				filepath.Join("templates", templateDir, entry.Name()),
+				path.Join(templateDir, entry.Name()),
 				destItem,
 			); err != nil {
 				return count, err
@@ -148,13 +206,13 @@ func (m *Manager) installDirectory(templateDir, destDir string, force, recursive
 		}
 	} else {
 		// Copy matching files (for agents)
-		entries, err := templates.FS.ReadDir("templates/" + templateDir)
+		entries, err := templates.ReadDirEntries(templateDir)
 		if err != nil {
 			return 0, err
 		}
 
 		for _, entry := range entries {
-			if entry.IsDir() {
+			if entry.IsDir() || !m.includes(templateDir, entry.Name()) {
 				continue
 			}
 			if pattern != "" && !matchPattern(entry.Name(), pattern) {
@@ -177,17 +235,17 @@ func (m *Manager) installDirectory(templateDir, destDir string, force, recursive
 }
 
 func (m *Manager) copyTree(srcPath, destPath string) error {
-	if err := os.MkdirAll(destPath, 0755); err != nil { //nolint:gosec // Template directories need to be readable
+	if err := fsutil.MkdirAll(destPath); err != nil {
 		return err
 	}
 
-	entries, err := templates.FS.ReadDir(srcPath)
+	entries, err := templates.ReadDirEntries(srcPath)
 	if err != nil {
 		return err
 	}
 
 	for _, entry := range entries {
-		srcItem := filepath.Join(srcPath, entry.Name())
+		srcItem := path.Join(srcPath, entry.Name())
 		destItem := filepath.Join(destPath, entry.Name())
 
 		if entry.IsDir() {
@@ -195,11 +253,14 @@ func (m *Manager) copyTree(srcPath, destPath string) error {
 				return err
 			}
 		} else {
-			data, err := templates.FS.ReadFile(srcItem)
+			data, err := templates.GetTemplate(srcItem)
 			if err != nil {
 				return err
 			}
-			if err := os.WriteFile(destItem, data, 0644); err != nil { //nolint:gosec // Template files need to be readable
+			if err := m.writeFile(destItem, data); err != nil {
+				return err
+			}
+			if err := m.recordTemplate(destItem, srcItem, data); err != nil {
 				return err
 			}
 		}
@@ -222,8 +283,17 @@ func (m *Manager) mergeSettings(force bool) error {
 	if err != nil {
 		return err
 	}
+	if m.profile != nil && len(m.profile.Permissions) > 0 {
+		allow := make([]interface{}, len(m.profile.Permissions))
+		for i, permission := range m.profile.Permissions {
+			allow[i] = permission
+		}
+		newSettings = config.Merge(newSettings, map[string]interface{}{
+			"permissions": map[string]interface{}{"allow": allow},
+		})
+	}
 
-	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil { //nolint:gosec // Template directories need to be readable
+	if err := fsutil.MkdirAll(filepath.Dir(destPath)); err != nil {
 		return err
 	}
 
@@ -233,9 +303,10 @@ func (m *Manager) mergeSettings(force bool) error {
 		if err != nil {
 			return err
 		}
-		if err := os.WriteFile(destPath, append(data, '\n'), 0644); err != nil { //nolint:gosec // Template files need to be readable //nolint:gosec // Template files need to be readable
+		if err := m.writeFile(destPath, append(data, '\n')); err != nil {
 			return err
 		}
+		m.recordSettings(newSettings)
 		relPath, _ := filepath.Rel(m.target, destPath)
 		fmt.Printf("  • %s (created)\n", relPath)
 		return nil
@@ -258,9 +329,10 @@ func (m *Manager) mergeSettings(force bool) error {
 		if err != nil {
 			return err
 		}
-		if err := os.WriteFile(destPath, append(data, '\n'), 0644); err != nil { //nolint:gosec // Template files need to be readable //nolint:gosec // Template files need to be readable
+		if err := m.writeFile(destPath, append(data, '\n')); err != nil {
 			return err
 		}
+		m.recordSettings(newSettings)
 		relPath, _ := filepath.Rel(m.target, destPath)
 		fmt.Printf("  • %s (overwritten)\n", relPath)
 		return nil
@@ -272,30 +344,89 @@ func (m *Manager) mergeSettings(force bool) error {
 	if err != nil {
 		return err
 	}
-	if err := os.WriteFile(destPath, append(data, '\n'), 0644); err != nil { //nolint:gosec // Template files need to be readable
+	if err := m.writeFile(destPath, append(data, '\n')); err != nil {
 		return err
 	}
+	m.recordSettings(config.Diff(merged, existingSettings))
 	relPath, _ := filepath.Rel(m.target, destPath)
 	fmt.Printf("  • %s (merged)\n", relPath)
 	return nil
 }
 
+// installLocalSettings creates an empty .claude/settings.local.json for
+// machine-specific values and makes sure git ignores it.
+func (m *Manager) installLocalSettings() error {
+	relPath := filepath.Join(".claude", "settings.local.json")
+	destPath := filepath.Join(m.target, relPath)
+
+	if _, err := os.Stat(destPath); os.IsNotExist(err) {
+		if err := config.SaveFile(destPath, map[string]interface{}{}); err != nil {
+			return err
+		}
+		if data, err := os.ReadFile(destPath); err == nil { //nolint:gosec // Path was just written
+			m.recordFile(destPath, data)
+		}
+		fmt.Printf("  • %s (created)\n", relPath)
+	} else {
+		fmt.Printf("  • %s (skipped)\n", relPath)
+	}
+
+	updated, err := m.ensureGitignored(filepath.ToSlash(relPath))
+	if err != nil {
+		return err
+	}
+	if updated {
+		fmt.Println("  • .gitignore (updated)")
+	}
+	return nil
+}
+
+// ensureGitignored appends pattern to the target's .gitignore unless git
+// already ignores it, recording the line so uninstall removes it. It does
+// nothing outside a git repository. Reports whether .gitignore changed.
+func (m *Manager) ensureGitignored(pattern string) (bool, error) {
+	if _, err := git.GetRepoRootFromPath(m.target); err != nil {
+		return false, nil
+	}
+	// git check-ignore exits 0 when the path is already ignored
+	if _, err := git.RunGit(m.target, "check-ignore", "-q", "--no-index", pattern); err == nil {
+		return false, nil
+	}
+
+	gitignorePath := filepath.Join(m.target, ".gitignore")
+	existing, err := os.ReadFile(gitignorePath) //nolint:gosec // Path is controlled, reading .gitignore
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	content := string(existing)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += pattern + "\n"
+	if err := m.writeFile(gitignorePath, []byte(content)); err != nil {
+		return false, err
+	}
+	m.recordGitignore(pattern)
+	return true, nil
+}
+
 func (m *Manager) configureMCP(force bool) error {
 	destPath := filepath.Join(m.target, ".mcp.json")
 
 	// New MCP servers to add
-	newServers := map[string]interface{}{
-		"context7": map[string]interface{}{
-			"type": "http",
-			"url":  "https://mcp.context7.com/mcp",
-		},
-		"linear": map[string]interface{}{
-			"type": "sse",
-			"url":  "https://mcp.linear.app/sse",
-		},
-	}
-
-	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil { //nolint:gosec // Template directories need to be readable
+	newServers := make(map[string]interface{})
+	for _, server := range mcp.DefaultServers() {
+		if m.profile != nil && !m.profile.IncludesMCPServer(server.Name) {
+			continue
+		}
+		newServers[server.Name] = server.ToMap()
+	}
+	if len(newServers) == 0 {
+		fmt.Println("  → No MCP servers in profile")
+		return nil
+	}
+
+	if err := fsutil.MkdirAll(filepath.Dir(destPath)); err != nil {
 		return err
 	}
 
@@ -326,6 +457,7 @@ func (m *Manager) configureMCP(force bool) error {
 		for serverName, serverConfig := range newServers {
 			if _, exists := servers[serverName]; !exists {
 				servers[serverName] = serverConfig
+				m.recordMCPServer(serverName)
 				addedAny = true
 			}
 		}
@@ -343,6 +475,9 @@ func (m *Manager) configureMCP(force bool) error {
 		mcpConfig = map[string]interface{}{
 			"mcpServers": newServers,
 		}
+		for serverName := range newServers {
+			m.recordMCPServer(serverName)
+		}
 		if _, err := os.Stat(destPath); err == nil {
 			status = "overwritten"
 		} else {
@@ -355,7 +490,7 @@ func (m *Manager) configureMCP(force bool) error {
 	if err != nil {
 		return err
 	}
-	if err := os.WriteFile(destPath, append(data, '\n'), 0644); err != nil { //nolint:gosec // Template files need to be readable
+	if err := m.writeFile(destPath, append(data, '\n')); err != nil {
 		return err
 	}
 
@@ -364,72 +499,18 @@ func (m *Manager) configureMCP(force bool) error {
 	return nil
 }
 
-func (m *Manager) indexRepository() error {
-	if _, err := exec.LookPath("claude"); err != nil {
-		return fmt.Errorf("claude CLI not found")
-	}
-
-	prompt := `Analyze this repository and provide a concise overview:
-- Main purpose and key technologies
-- Directory structure (2-3 levels max)
-- Entry points and main files
-- Build/run commands (check for package.json scripts, Makefile targets, Justfile recipes, etc.)
-- Available scripts and automation tools
-
-Format as clean markdown starting at heading level 3 (###), keep it brief (under 500 words).`
-
-	fmt.Print("  → Indexing repository with Claude CLI...")
-
-	cmdCtx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
-	defer cancel()
-
-	cmd := exec.CommandContext(cmdCtx, "claude", "--print", "--output-format", "text", prompt)
-	cmd.Dir = m.target
-	cmd.Env = os.Environ()
-
-	output, err := cmd.Output()
-	if err != nil {
-		return err
-	}
-
-	indexContent := strings.TrimSpace(string(output))
-	if indexContent == "" {
-		return fmt.Errorf("empty output from Claude CLI")
-	}
-
-	if err := m.insertRepositoryIndex(indexContent); err != nil {
-		return err
-	}
-
-	fmt.Println(" done")
-	return nil
-}
-
-func (m *Manager) insertRepositoryIndex(indexContent string) error {
-	claudeMDPath := filepath.Join(m.target, "CLAUDE.md")
-	if _, err := os.Stat(claudeMDPath); os.IsNotExist(err) {
-		return fmt.Errorf("CLAUDE.md not found")
-	}
-
-	data, err := os.ReadFile(claudeMDPath) //nolint:gosec // Path is controlled, reading template files
-	if err != nil {
-		return err
+// includes reports whether the profile selects the named template entry.
+func (m *Manager) includes(templateDir, name string) bool {
+	if m.profile == nil {
+		return true
 	}
-
-	content := string(data)
-	startMarker := "<!-- REPOSITORY_INDEX_START -->"
-	endMarker := "<!-- REPOSITORY_INDEX_END -->"
-
-	startIdx := strings.Index(content, startMarker)
-	endIdx := strings.Index(content, endMarker)
-
-	if startIdx == -1 || endIdx == -1 {
-		return fmt.Errorf("repository index markers not found")
+	switch templateDir {
+	case "agents":
+		return m.profile.IncludesAgent(name)
+	case "skills":
+		return m.profile.IncludesSkill(name)
 	}
-
-	updatedContent := content[:startIdx+len(startMarker)] + "\n" + indexContent + "\n" + content[endIdx:]
-
-	return os.WriteFile(claudeMDPath, []byte(updatedContent), 0644) //nolint:gosec // Template files need to be readable
+	return true
 }
 
 func matchPattern(name, pattern string) bool {
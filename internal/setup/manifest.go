@@ -0,0 +1,179 @@
+package setup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/fsutil"
+)
+
+// ManifestFile is written under .claude by init to record what it installed,
+// so uninstall can remove exactly that.
+const ManifestFile = "agentctl-manifest.json"
+
+// baseDir holds, under .claude, a pristine copy of each installed template.
+// upgrade uses it as the common ancestor for three-way merges.
+const baseDir = ".agentctl-base"
+
+// Manifest records everything agentctl init installed into a target.
+// Paths are relative to the target directory.
+type Manifest struct {
+	// Profile is the template profile used for the install.
+	Profile string `json:"profile,omitempty"`
+	// Files maps each file agentctl wrote to the SHA-256 of its contents.
+	Files map[string]string `json:"files"`
+	// Templates maps installed files to the template they were copied from.
+	Templates map[string]string `json:"templates,omitempty"`
+	// Settings holds the values init merged into .claude/settings.json.
+	Settings map[string]interface{} `json:"settings,omitempty"`
+	// MCPServers lists the servers init added to .mcp.json.
+	MCPServers []string `json:"mcp_servers,omitempty"`
+	// Gitignore lists the lines init appended to .gitignore.
+	Gitignore []string `json:"gitignore,omitempty"`
+}
+
+func (m *Manager) manifestPath() string {
+	return filepath.Join(m.target, ".claude", ManifestFile)
+}
+
+// loadManifest reads the target's manifest, returning an empty one if none exists.
+func (m *Manager) loadManifest() (*Manifest, error) {
+	manifest := &Manifest{Files: make(map[string]string), Templates: make(map[string]string)}
+	data, err := os.ReadFile(m.manifestPath()) //nolint:gosec // Path is inside the install target
+	if os.IsNotExist(err) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", m.manifestPath(), err)
+	}
+	if manifest.Files == nil {
+		manifest.Files = make(map[string]string)
+	}
+	if manifest.Templates == nil {
+		manifest.Templates = make(map[string]string)
+	}
+	return manifest, nil
+}
+
+func (m *Manager) saveManifest() error {
+	if m.manifest == nil {
+		return nil
+	}
+	sort.Strings(m.manifest.MCPServers)
+	sort.Strings(m.manifest.Gitignore)
+	data, err := json.MarshalIndent(m.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := fsutil.MkdirAll(filepath.Dir(m.manifestPath())); err != nil {
+		return err
+	}
+	return fsutil.WriteFile(m.manifestPath(), append(data, '\n'), fsutil.FileMode())
+}
+
+// recordFile notes that agentctl wrote data to destPath.
+func (m *Manager) recordFile(destPath string, data []byte) {
+	if m.manifest == nil {
+		return
+	}
+	relPath, err := filepath.Rel(m.target, destPath)
+	if err != nil {
+		return
+	}
+	m.manifest.Files[relPath] = hashContent(data)
+}
+
+// recordTemplate notes that agentctl copied templatePath to destPath and
+// saves a base copy of the template for later upgrades.
+func (m *Manager) recordTemplate(destPath, templatePath string, data []byte) error {
+	if m.manifest == nil {
+		return nil
+	}
+	relPath, err := filepath.Rel(m.target, destPath)
+	if err != nil {
+		return nil
+	}
+	m.recordFile(destPath, data)
+	m.manifest.Templates[relPath] = filepath.ToSlash(templatePath)
+
+	if err := m.ignoreInstallState(); err != nil {
+		return err
+	}
+	basePath := m.basePath(relPath)
+	if err := fsutil.MkdirAll(filepath.Dir(basePath)); err != nil {
+		return err
+	}
+	return fsutil.WriteFile(basePath, data, fsutil.FileMode())
+}
+
+// ignoreInstallState keeps the manifest and base copies, which describe this
+// checkout's install, out of version control. Runs once per manager.
+func (m *Manager) ignoreInstallState() error {
+	if m.stateIgnored {
+		return nil
+	}
+	m.stateIgnored = true
+	for _, pattern := range []string{
+		path.Join(".claude", baseDir) + "/",
+		path.Join(".claude", ManifestFile),
+	} {
+		if _, err := m.ensureGitignored(pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// basePath returns where the base copy of an installed file is kept.
+func (m *Manager) basePath(relPath string) string {
+	return filepath.Join(m.target, ".claude", baseDir, relPath)
+}
+
+// recordSettings notes values merged into settings.json.
+func (m *Manager) recordSettings(added map[string]interface{}) {
+	if m.manifest == nil || len(added) == 0 {
+		return
+	}
+	if m.manifest.Settings == nil {
+		m.manifest.Settings = make(map[string]interface{})
+	}
+	m.manifest.Settings = config.Merge(m.manifest.Settings, added)
+}
+
+func (m *Manager) recordMCPServer(name string) {
+	if m.manifest == nil || contains(m.manifest.MCPServers, name) {
+		return
+	}
+	m.manifest.MCPServers = append(m.manifest.MCPServers, name)
+}
+
+func (m *Manager) recordGitignore(line string) {
+	if m.manifest == nil || contains(m.manifest.Gitignore, line) {
+		return
+	}
+	m.manifest.Gitignore = append(m.manifest.Gitignore, line)
+}
+
+func hashContent(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func contains(list []string, item string) bool {
+	for _, existing := range list {
+		if existing == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,238 @@
+package setup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/config"
+	"github.com/ryantking/agentctl/internal/fsutil"
+)
+
+// Uninstall removes everything recorded in the install manifest: files init
+// wrote, settings it merged, MCP servers it added, and .gitignore entries.
+// Files changed since install are kept unless force is set. With dryRun,
+// only reports what would be removed.
+func (m *Manager) Uninstall(dryRun, force bool) error {
+	if _, err := os.Stat(m.manifestPath()); os.IsNotExist(err) {
+		return fmt.Errorf("no install manifest at %s (only installs by this version of agentctl init can be uninstalled)", m.manifestPath())
+	}
+	if !dryRun {
+		unlock, err := m.Lock()
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+	manifest, err := m.loadManifest()
+	if err != nil {
+		return err
+	}
+
+	verb := "removed"
+	if dryRun {
+		verb = "would remove"
+	}
+
+	// 1. Remove installed files
+	fmt.Println("Removing installed files...")
+	paths := make([]string, 0, len(manifest.Files))
+	for relPath := range manifest.Files {
+		paths = append(paths, relPath)
+	}
+	sort.Strings(paths)
+
+	dirs := make(map[string]bool)
+	kept := make(map[string]string)
+	for _, relPath := range paths {
+		destPath := filepath.Join(m.target, relPath)
+		data, err := os.ReadFile(destPath) //nolint:gosec // Path comes from the install manifest
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if hashContent(data) != manifest.Files[relPath] && !force {
+			fmt.Printf("  • %s (modified, kept)\n", relPath)
+			kept[relPath] = manifest.Files[relPath]
+			continue
+		}
+		if !dryRun {
+			if err := os.Remove(destPath); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("  • %s (%s)\n", relPath, verb)
+		dirs[filepath.Dir(destPath)] = true
+	}
+	if !dryRun {
+		m.pruneEmptyDirs(dirs)
+	}
+
+	// 2. Remove merged settings
+	if len(manifest.Settings) > 0 {
+		fmt.Println("Removing merged settings...")
+		if err := m.uninstallSettings(manifest.Settings, dryRun, verb); err != nil {
+			return err
+		}
+	}
+
+	// 3. Remove MCP servers
+	if len(manifest.MCPServers) > 0 {
+		fmt.Println("Removing MCP servers...")
+		if err := m.uninstallMCPServers(manifest.MCPServers, dryRun, verb); err != nil {
+			return err
+		}
+	}
+
+	// 4. Remove .gitignore entries
+	if len(manifest.Gitignore) > 0 {
+		fmt.Println("Removing .gitignore entries...")
+		if err := m.uninstallGitignore(manifest.Gitignore, dryRun, verb); err != nil {
+			return err
+		}
+	}
+
+	if dryRun {
+		fmt.Println("\nDry run: nothing was changed")
+		return nil
+	}
+
+	if len(kept) > 0 {
+		// Keep the remaining files in the manifest so a later --force run can finish the job
+		m.manifest = &Manifest{Files: kept}
+		if err := m.saveManifest(); err != nil {
+			return err
+		}
+		fmt.Printf("\n✓ Uninstalled (%d modified file(s) kept; use --force to remove them)\n", len(kept))
+		return nil
+	}
+	if err := os.RemoveAll(filepath.Join(m.target, ".claude", baseDir)); err != nil {
+		return err
+	}
+	if err := os.Remove(m.manifestPath()); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	m.pruneEmptyDirs(map[string]bool{filepath.Dir(m.manifestPath()): true})
+	fmt.Println("\n✓ Uninstalled")
+	return nil
+}
+
+func (m *Manager) uninstallSettings(added map[string]interface{}, dryRun bool, verb string) error {
+	relPath := filepath.Join(".claude", "settings.json")
+	destPath := filepath.Join(m.target, relPath)
+	if _, err := os.Stat(destPath); os.IsNotExist(err) {
+		return nil
+	}
+
+	settings, err := config.LoadFile(destPath)
+	if err != nil {
+		return err
+	}
+	remaining := config.Subtract(settings, added)
+
+	if len(remaining) == 0 {
+		if !dryRun {
+			if err := os.Remove(destPath); err != nil {
+				return err
+			}
+			m.pruneEmptyDirs(map[string]bool{filepath.Dir(destPath): true})
+		}
+		fmt.Printf("  • %s (%s)\n", relPath, verb)
+		return nil
+	}
+
+	if !dryRun {
+		if err := config.SaveFile(destPath, remaining); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("  • %s (%s agentctl settings, kept %d other key(s))\n", relPath, verb, len(remaining))
+	return nil
+}
+
+func (m *Manager) uninstallMCPServers(names []string, dryRun bool, verb string) error {
+	destPath := filepath.Join(m.target, ".mcp.json")
+	if _, err := os.Stat(destPath); os.IsNotExist(err) {
+		return nil
+	}
+
+	data, err := config.LoadFile(destPath)
+	if err != nil {
+		return err
+	}
+	servers, _ := data["mcpServers"].(map[string]interface{})
+	for _, name := range names {
+		if _, exists := servers[name]; !exists {
+			continue
+		}
+		delete(servers, name)
+		fmt.Printf("  • %s (%s)\n", name, verb)
+	}
+	if dryRun {
+		return nil
+	}
+
+	if len(servers) == 0 {
+		delete(data, "mcpServers")
+	}
+	if len(data) == 0 {
+		return os.Remove(destPath)
+	}
+	return config.SaveFile(destPath, data)
+}
+
+func (m *Manager) uninstallGitignore(lines []string, dryRun bool, verb string) error {
+	gitignorePath := filepath.Join(m.target, ".gitignore")
+	existing, err := os.ReadFile(gitignorePath) //nolint:gosec // Path is controlled, reading .gitignore
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var kept []string
+	for _, line := range strings.Split(strings.TrimSuffix(string(existing), "\n"), "\n") {
+		if contains(lines, line) {
+			fmt.Printf("  • %s (%s)\n", line, verb)
+			continue
+		}
+		kept = append(kept, line)
+	}
+	if dryRun {
+		return nil
+	}
+
+	if len(kept) == 0 || (len(kept) == 1 && kept[0] == "") {
+		return os.Remove(gitignorePath)
+	}
+	return fsutil.WriteFile(gitignorePath, []byte(strings.Join(kept, "\n")+"\n"), fsutil.FileMode())
+}
+
+// pruneEmptyDirs removes each directory, and its parents up to the target,
+// while they are empty.
+func (m *Manager) pruneEmptyDirs(dirs map[string]bool) {
+	sorted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	// Deepest first so children are removed before their parents
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+
+	for _, dir := range sorted {
+		for dir != m.target && strings.HasPrefix(dir, m.target) {
+			entries, err := os.ReadDir(dir)
+			if err != nil || len(entries) > 0 {
+				break
+			}
+			if err := os.Remove(dir); err != nil {
+				break
+			}
+			dir = filepath.Dir(dir)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package setup
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/fsutil"
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/templates"
+)
+
+// Upgrade brings installed templates up to date with the current templates.
+// Unmodified files are replaced, locally edited files get a three-way merge
+// against the base copy saved at install time, and templates added since the
+// install are installed. Conflicts are left marked in the file and reported.
+// With dryRun, only reports what would change.
+func (m *Manager) Upgrade(dryRun bool) (err error) {
+	if _, err := os.Stat(m.manifestPath()); os.IsNotExist(err) {
+		return fmt.Errorf("no install manifest at %s (run agentctl init first)", m.manifestPath())
+	}
+	if !dryRun {
+		unlock, err := m.Lock()
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+	if m.manifest, err = m.loadManifest(); err != nil {
+		return err
+	}
+	if m.profile == nil && m.manifest.Profile != "" {
+		if m.profile, err = templates.GetProfile(m.manifest.Profile); err != nil {
+			return err
+		}
+	}
+
+	available, err := m.templateFiles()
+	if err != nil {
+		return err
+	}
+	for relPath, templatePath := range m.manifest.Templates {
+		if _, ok := available[relPath]; !ok {
+			available[relPath] = templatePath
+		}
+	}
+	paths := make([]string, 0, len(available))
+	for relPath := range available {
+		paths = append(paths, relPath)
+	}
+	sort.Strings(paths)
+
+	// 1. Upgrade template files
+	fmt.Println("Upgrading templates...")
+	var conflicted []string
+	changed := 0
+	for _, relPath := range paths {
+		status, conflicts, err := m.upgradeFile(relPath, available[relPath], dryRun)
+		if err != nil {
+			return fmt.Errorf("%s: %w", relPath, err)
+		}
+		if status == "" {
+			continue
+		}
+		if conflicts > 0 {
+			conflicted = append(conflicted, relPath)
+		}
+		changed++
+		fmt.Printf("  • %s (%s)\n", relPath, status)
+	}
+	if changed == 0 {
+		fmt.Println("  → All templates up to date")
+	}
+
+	if dryRun {
+		fmt.Println("\nDry run: nothing was changed")
+		return nil
+	}
+
+	defer func() {
+		if saveErr := m.saveManifest(); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}()
+
+	// 2. Merge new settings
+	fmt.Println("Merging settings.json...")
+	if err := m.mergeSettings(false); err != nil {
+		return err
+	}
+
+	// 3. Add new MCP servers
+	fmt.Println("Configuring MCP servers...")
+	if err := m.configureMCP(false); err != nil {
+		return err
+	}
+
+	if len(conflicted) > 0 {
+		fmt.Printf("\nResolve the conflict markers in:\n  %s\n", strings.Join(conflicted, "\n  "))
+		return fmt.Errorf("%d file(s) have merge conflicts", len(conflicted))
+	}
+
+	fmt.Println("\n✓ Upgrade complete")
+	return nil
+}
+
+// upgradeFile upgrades a single installed file. It returns a status to print
+// (empty when nothing changed) and the number of merge conflicts.
+func (m *Manager) upgradeFile(relPath, templatePath string, dryRun bool) (string, int, error) {
+	destPath := filepath.Join(m.target, relPath)
+	verb := func(done, planned string) string {
+		if dryRun {
+			return planned
+		}
+		return done
+	}
+
+	newData, err := templates.GetTemplate(templatePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "no longer bundled, kept", 0, nil
+	}
+	if err != nil {
+		return "", 0, err
+	}
+
+	recordedHash, tracked := m.manifest.Files[relPath]
+	current, err := os.ReadFile(destPath) //nolint:gosec // Path comes from the install manifest
+	if os.IsNotExist(err) {
+		if tracked {
+			// Deleted locally; respect that
+			return "", 0, nil
+		}
+		if !dryRun {
+			if err := m.writeUpgrade(destPath, templatePath, newData, newData); err != nil {
+				return "", 0, err
+			}
+		}
+		return verb("added", "would add"), 0, nil
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	if !tracked {
+		// The user's own file (init skipped it); leave it alone
+		return "", 0, nil
+	}
+
+	base, baseErr := os.ReadFile(m.basePath(relPath))
+	switch {
+	case string(current) == string(newData):
+		if !dryRun {
+			if err := m.recordTemplate(destPath, templatePath, newData); err != nil {
+				return "", 0, err
+			}
+		}
+		return "", 0, nil
+	case baseErr == nil && string(base) == string(newData):
+		// Template unchanged since install; local edits stand
+		return "", 0, nil
+	case hashContent(current) == recordedHash:
+		if !dryRun {
+			if err := m.writeUpgrade(destPath, templatePath, newData, newData); err != nil {
+				return "", 0, err
+			}
+		}
+		return verb("updated", "would update"), 0, nil
+	case baseErr != nil:
+		return "modified locally and no base copy to merge against, skipped", 0, nil
+	}
+
+	merged, conflicts, err := git.MergeText(string(current), string(base), string(newData), [2]string{"local", "template"})
+	if err != nil {
+		return "", 0, err
+	}
+	if !dryRun {
+		if err := m.writeUpgrade(destPath, templatePath, newData, []byte(merged)); err != nil {
+			return "", 0, err
+		}
+	}
+	if conflicts > 0 {
+		return fmt.Sprintf("%s, %d conflict(s)", verb("merged", "would merge"), conflicts), conflicts, nil
+	}
+	return verb("merged", "would merge"), 0, nil
+}
+
+// writeUpgrade writes content to destPath, recording templateData as the new base.
+func (m *Manager) writeUpgrade(destPath, templatePath string, templateData, content []byte) error {
+	if err := fsutil.MkdirAll(filepath.Dir(destPath)); err != nil {
+		return err
+	}
+	if err := m.writeFile(destPath, content); err != nil {
+		return err
+	}
+	if err := m.recordTemplate(destPath, templatePath, templateData); err != nil {
+		return err
+	}
+	m.recordFile(destPath, content)
+	return nil
+}
+
+// templateFiles maps each file init would install for the current profile,
+// relative to the target, to its template path.
+func (m *Manager) templateFiles() (map[string]string, error) {
+	files := map[string]string{"CLAUDE.md": "CLAUDE.md"}
+
+	agents, err := templates.ReadDirEntries("agents")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range agents {
+		if entry.IsDir() || !matchPattern(entry.Name(), "*.md") || !m.includes("agents", entry.Name()) {
+			continue
+		}
+		files[filepath.Join(".claude", "agents", entry.Name())] = path.Join("agents", entry.Name())
+	}
+
+	skills, err := templates.ReadDirEntries("skills")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range skills {
+		if !entry.IsDir() || !m.includes("skills", entry.Name()) {
+			continue
+		}
+		if err := collectTemplateTree(path.Join("skills", entry.Name()), filepath.Join(".claude", "skills", entry.Name()), files); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func collectTemplateTree(templateDir, destDir string, files map[string]string) error {
+	entries, err := templates.ReadDirEntries(templateDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		templatePath := path.Join(templateDir, entry.Name())
+		destPath := filepath.Join(destDir, entry.Name())
+		if entry.IsDir() {
+			if err := collectTemplateTree(templatePath, destPath, files); err != nil {
+				return err
+			}
+			continue
+		}
+		files[destPath] = templatePath
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+// Package stats keeps opt-in, local-only usage statistics: how often each
+// command runs and how long agent calls take. Nothing is ever sent over the
+// network; the data lives in a single JSON file in the user config directory.
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/fsutil"
+)
+
+// Usage aggregates invocations of one command or agent.
+type Usage struct {
+	Count    int           `json:"count"`
+	Failures int           `json:"failures,omitempty"`
+	Total    time.Duration `json:"total_ns"`
+	Max      time.Duration `json:"max_ns"`
+}
+
+// Average returns the mean duration per invocation.
+func (u *Usage) Average() time.Duration {
+	if u.Count == 0 {
+		return 0
+	}
+	return u.Total / time.Duration(u.Count)
+}
+
+func (u *Usage) add(o *Usage) {
+	u.Count += o.Count
+	u.Failures += o.Failures
+	u.Total += o.Total
+	if o.Max > u.Max {
+		u.Max = o.Max
+	}
+}
+
+// Stats is the contents of the stats file.
+type Stats struct {
+	Enabled  bool              `json:"enabled"`
+	Since    time.Time         `json:"since,omitempty"`
+	LastUsed time.Time         `json:"last_used,omitempty"`
+	Commands map[string]*Usage `json:"commands,omitempty"`
+	Agents   map[string]*Usage `json:"agents,omitempty"`
+}
+
+var (
+	mu       sync.Mutex
+	commands = make(map[string]*Usage)
+	agents   = make(map[string]*Usage)
+)
+
+// RecordCommand counts one run of a command. Recordings are buffered until
+// Flush and discarded there unless stats are enabled.
+func RecordCommand(name string, d time.Duration, err error) {
+	record(&commands, name, d, err)
+}
+
+// RecordAgent counts one agent call and its duration.
+func RecordAgent(name string, d time.Duration, err error) {
+	record(&agents, name, d, err)
+}
+
+// record takes a pointer to the buffer because Flush swaps the maps; they
+// must only be read under mu.
+func record(into *map[string]*Usage, name string, d time.Duration, err error) {
+	u := &Usage{Count: 1, Total: d, Max: d}
+	if err != nil {
+		u.Failures = 1
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if (*into)[name] == nil {
+		(*into)[name] = &Usage{}
+	}
+	(*into)[name].add(u)
+}
+
+// Flush merges buffered recordings into the stats file when stats are enabled.
+func Flush() error {
+	mu.Lock()
+	pendingCommands, pendingAgents := commands, agents
+	commands, agents = make(map[string]*Usage), make(map[string]*Usage)
+	mu.Unlock()
+
+	if len(pendingCommands) == 0 && len(pendingAgents) == 0 {
+		return nil
+	}
+
+	return update(func(s *Stats) bool {
+		if !s.Enabled {
+			return false
+		}
+		merge(&s.Commands, pendingCommands)
+		merge(&s.Agents, pendingAgents)
+		s.LastUsed = time.Now().UTC()
+		return true
+	})
+}
+
+// lockTimeout bounds how long an update waits for another process to finish
+// writing the stats file.
+const lockTimeout = 2 * time.Second
+
+// update applies fn to the stats file under a lock, so concurrent runs (hooks
+// fire in parallel) never drop each other's counts. The file is saved only
+// when fn reports a change.
+func update(fn func(*Stats) bool) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	if err := fsutil.MkdirAll(dir); err != nil {
+		return err
+	}
+	unlock, err := fsutil.LockWait(dir, lockTimeout)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s, err := Load()
+	if err != nil {
+		return err
+	}
+	if !fn(s) {
+		return nil
+	}
+	return Save(s)
+}
+
+func merge(into *map[string]*Usage, from map[string]*Usage) {
+	if *into == nil {
+		*into = make(map[string]*Usage)
+	}
+	for name, u := range from {
+		if (*into)[name] == nil {
+			(*into)[name] = &Usage{}
+		}
+		(*into)[name].add(u)
+	}
+}
+
+// Path returns the stats file location.
+func Path() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "agentctl", "stats.json"), nil
+}
+
+// Load reads the stats file. A missing file yields disabled, empty stats.
+func Load() (*Stats, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Stats{}
+	data, err := os.ReadFile(path) //nolint:gosec // Path is inside the user config directory
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Save writes the stats file atomically so concurrent hook invocations never
+// observe a partial file.
+func Save(s *Stats) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := fsutil.MkdirAll(filepath.Dir(path)); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return fsutil.WriteFile(path, append(data, '\n'), fsutil.FileMode())
+}
+
+// SetEnabled turns recording on or off, keeping any collected data.
+func SetEnabled(enabled bool) error {
+	return update(func(s *Stats) bool {
+		s.Enabled = enabled
+		if enabled && s.Since.IsZero() {
+			s.Since = time.Now().UTC()
+		}
+		return true
+	})
+}
+
+// Reset clears collected data without changing whether recording is enabled.
+func Reset() error {
+	return update(func(s *Stats) bool {
+		*s = Stats{Enabled: s.Enabled}
+		if s.Enabled {
+			s.Since = time.Now().UTC()
+		}
+		return true
+	})
+}
@@ -0,0 +1,97 @@
+package stats
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFlush(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	// Disabled: recordings are dropped and no file is written
+	RecordCommand("agentctl init", time.Second, nil)
+	if err := Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	s, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if s.Enabled || len(s.Commands) != 0 {
+		t.Fatalf("disabled stats recorded data: %+v", s)
+	}
+
+	if err := SetEnabled(true); err != nil {
+		t.Fatalf("SetEnabled() error = %v", err)
+	}
+	RecordCommand("agentctl init", time.Second, nil)
+	RecordCommand("agentctl init", 3*time.Second, errors.New("boom"))
+	RecordAgent("claude", 2*time.Second, nil)
+	if err := Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	RecordCommand("agentctl init", time.Second, nil)
+	if err := Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	s, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	init := s.Commands["agentctl init"]
+	if init == nil || init.Count != 3 || init.Failures != 1 || init.Max != 3*time.Second || init.Average() != 5*time.Second/3 {
+		t.Errorf("commands[agentctl init] = %+v", init)
+	}
+	if claude := s.Agents["claude"]; claude == nil || claude.Count != 1 {
+		t.Errorf("agents[claude] = %+v", claude)
+	}
+
+	if err := Reset(); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	s, _ = Load()
+	if !s.Enabled || len(s.Commands) != 0 {
+		t.Errorf("after Reset() = %+v, want enabled and empty", s)
+	}
+}
+
+func TestFlushConcurrent(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	if err := SetEnabled(true); err != nil {
+		t.Fatalf("SetEnabled() error = %v", err)
+	}
+
+	const workers, runs = 8, 25
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*runs)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range runs {
+				RecordCommand("agentctl hook post-edit", time.Millisecond, nil)
+				if err := Flush(); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	s, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := s.Commands["agentctl hook post-edit"]; got == nil || got.Count != workers*runs {
+		t.Errorf("commands[agentctl hook post-edit] = %+v, want count %d", got, workers*runs)
+	}
+}
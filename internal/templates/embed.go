@@ -3,21 +3,35 @@ package templates
 
 import (
 	"embed"
-	"path/filepath"
+	"io/fs"
+	"path"
 )
 
 //go:embed all:templates
 // FS is the embedded filesystem containing template files.
 var FS embed.FS
 
-// GetTemplate reads a template file from the embedded filesystem.
+// active is the filesystem templates are read from: the bundled templates,
+// possibly layered under an override (see Override).
+var active = bundled()
+
+// bundled returns the embedded templates rooted at the templates directory.
+func bundled() fs.FS {
+	sub, err := fs.Sub(FS, "templates")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// GetTemplate reads a template file.
 func GetTemplate(name string) ([]byte, error) {
-	return FS.ReadFile(filepath.Join("templates", name))
+	return fs.ReadFile(active, path.Clean(name))
 }
 
-// ReadDir reads a directory from the embedded filesystem.
+// ReadDir reads the names of the entries in a template directory.
 func ReadDir(name string) ([]string, error) {
-	entries, err := FS.ReadDir(filepath.Join("templates", name))
+	entries, err := ReadDirEntries(name)
 	if err != nil {
 		return nil, err
 	}
@@ -27,3 +41,8 @@ func ReadDir(name string) ([]string, error) {
 	}
 	return files, nil
 }
+
+// ReadDirEntries reads the entries of a template directory.
+func ReadDirEntries(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(active, path.Clean(name))
+}
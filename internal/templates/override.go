@@ -0,0 +1,146 @@
+package templates
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ryantking/agentctl/internal/fsutil"
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// EnvVar names a local directory or git URL whose templates override the
+// bundled ones. Files present in the override replace bundled files with the
+// same path; everything else falls back to the bundled templates.
+const EnvVar = "AGENTCTL_TEMPLATES"
+
+// Override layers the templates at location over the bundled templates.
+// location is a local directory or a git URL, optionally suffixed with
+// #<ref> to pick a branch or tag. Git sources are cloned into the user cache
+// directory and refreshed on each call; if refreshing fails the cached copy
+// is used.
+func Override(location string) error {
+	dir := location
+	if isGitURL(location) {
+		var err error
+		dir, err = fetchTemplates(location)
+		if err != nil {
+			return err
+		}
+	}
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("template directory %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("template directory %s is not a directory", dir)
+	}
+
+	active = overlayFS{override: os.DirFS(dir), base: bundled()}
+	return nil
+}
+
+func isGitURL(location string) bool {
+	for _, prefix := range []string{"https://", "http://", "ssh://", "git://", "file://", "git@"} {
+		if strings.HasPrefix(location, prefix) {
+			return true
+		}
+	}
+	url, _, _ := strings.Cut(location, "#")
+	return strings.HasSuffix(url, ".git")
+}
+
+// fetchTemplates clones or refreshes a git template source and returns its checkout path.
+func fetchTemplates(location string) (string, error) {
+	url, ref, _ := strings.Cut(location, "#")
+
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(location))
+	dir := filepath.Join(cacheDir, "agentctl", "templates", hex.EncodeToString(sum[:8]))
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		target := "HEAD"
+		if ref != "" {
+			target = ref
+		}
+		if _, err := git.RunGit(dir, "fetch", "--depth", "1", "origin", target); err != nil {
+			slog.Warn("failed to update templates, using cached copy", "url", url, "err", err)
+			return dir, nil
+		}
+		if _, err := git.RunGit(dir, "reset", "--hard", "FETCH_HEAD"); err != nil {
+			return "", fmt.Errorf("failed to update templates from %s: %w", url, err)
+		}
+		return dir, nil
+	}
+
+	if err := fsutil.MkdirAll(filepath.Dir(dir)); err != nil {
+		return "", err
+	}
+	args := []string{"clone", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, url, dir)
+	if _, err := git.RunGit(filepath.Dir(dir), args...); err != nil {
+		return "", fmt.Errorf("failed to clone templates from %s: %w", url, err)
+	}
+	return dir, nil
+}
+
+// overlayFS serves files from override when present, otherwise from base.
+// Directory listings are the union of both.
+type overlayFS struct {
+	override fs.FS
+	base     fs.FS
+}
+
+func (o overlayFS) Open(name string) (fs.File, error) {
+	if f, err := o.override.Open(name); err == nil {
+		return f, nil
+	}
+	return o.base.Open(name)
+}
+
+func (o overlayFS) ReadFile(name string) ([]byte, error) {
+	if data, err := fs.ReadFile(o.override, name); err == nil {
+		return data, nil
+	}
+	return fs.ReadFile(o.base, name)
+}
+
+func (o overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	overrideEntries, overrideErr := fs.ReadDir(o.override, name)
+	baseEntries, baseErr := fs.ReadDir(o.base, name)
+	if overrideErr != nil && baseErr != nil {
+		if errors.Is(overrideErr, fs.ErrNotExist) {
+			return nil, baseErr
+		}
+		return nil, overrideErr
+	}
+
+	entries := make(map[string]fs.DirEntry)
+	for _, entry := range baseEntries {
+		entries[entry.Name()] = entry
+	}
+	for _, entry := range overrideEntries {
+		entries[entry.Name()] = entry
+	}
+
+	result := make([]fs.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, entry)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name() < result[j].Name() })
+	return result, nil
+}
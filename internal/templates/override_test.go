@@ -0,0 +1,52 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOverrideLayersOverBundled(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "agents"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "CLAUDE.md"), []byte("# Custom\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "agents", "custom.md"), []byte("custom"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { active = bundled() })
+	if err := Override(dir); err != nil {
+		t.Fatalf("Override() error = %v", err)
+	}
+
+	data, err := GetTemplate("CLAUDE.md")
+	if err != nil || string(data) != "# Custom\n" {
+		t.Errorf("GetTemplate(CLAUDE.md) = %q, %v; want override content", data, err)
+	}
+	if _, err := GetTemplate("settings.json"); err != nil {
+		t.Errorf("GetTemplate(settings.json) should fall back to bundled: %v", err)
+	}
+
+	agents, err := ReadDir("agents")
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]bool{}
+	for _, name := range agents {
+		found[name] = true
+	}
+	if !found["custom.md"] || !found["engineer.md"] {
+		t.Errorf("ReadDir(agents) = %v, want bundled and override agents", agents)
+	}
+}
+
+func TestOverrideRejectsMissingDirectory(t *testing.T) {
+	t.Cleanup(func() { active = bundled() })
+	if err := Override(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Override() of a missing directory succeeded")
+	}
+}
@@ -0,0 +1,100 @@
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Profile selects which bundled templates agentctl init installs.
+// A "*" entry in a list selects everything of that kind.
+type Profile struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Agents      []string `json:"agents"`
+	Skills      []string `json:"skills"`
+	MCPServers  []string `json:"mcp_servers"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// profileManifest is the layout of profiles.json.
+type profileManifest struct {
+	Default  string             `json:"default"`
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// IncludesAgent reports whether the profile installs the named agent.
+func (p *Profile) IncludesAgent(name string) bool {
+	return includes(p.Agents, strings.TrimSuffix(name, ".md"))
+}
+
+// IncludesSkill reports whether the profile installs the named skill.
+func (p *Profile) IncludesSkill(name string) bool {
+	return includes(p.Skills, name)
+}
+
+// IncludesMCPServer reports whether the profile configures the named MCP server.
+func (p *Profile) IncludesMCPServer(name string) bool {
+	return includes(p.MCPServers, name)
+}
+
+func includes(list []string, name string) bool {
+	for _, item := range list {
+		if item == "*" || item == name {
+			return true
+		}
+	}
+	return false
+}
+
+func loadProfiles() (*profileManifest, error) {
+	data, err := GetTemplate("profiles.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles manifest: %w", err)
+	}
+	var manifest profileManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse profiles manifest: %w", err)
+	}
+	for name, profile := range manifest.Profiles {
+		profile.Name = name
+		manifest.Profiles[name] = profile
+	}
+	return &manifest, nil
+}
+
+// Profiles returns all profiles sorted by name, and the name of the default profile.
+func Profiles() ([]Profile, string, error) {
+	manifest, err := loadProfiles()
+	if err != nil {
+		return nil, "", err
+	}
+	profiles := make([]Profile, 0, len(manifest.Profiles))
+	for _, profile := range manifest.Profiles {
+		profiles = append(profiles, profile)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, manifest.Default, nil
+}
+
+// GetProfile returns the named profile, or the default profile if name is empty.
+func GetProfile(name string) (*Profile, error) {
+	manifest, err := loadProfiles()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = manifest.Default
+	}
+	profile, ok := manifest.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(manifest.Profiles))
+		for n := range manifest.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(names, ", "))
+	}
+	return &profile, nil
+}
@@ -0,0 +1,44 @@
+package templates
+
+import (
+	"testing"
+)
+
+func TestProfilesReferenceBundledTemplates(t *testing.T) {
+	profiles, defaultName, err := Profiles()
+	if err != nil {
+		t.Fatalf("Profiles() error = %v", err)
+	}
+	if _, err := GetProfile(defaultName); err != nil {
+		t.Fatalf("default profile %q: %v", defaultName, err)
+	}
+
+	agents, err := ReadDir("agents")
+	if err != nil {
+		t.Fatal(err)
+	}
+	skills, err := ReadDir("skills")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundled := make(map[string]bool)
+	for _, name := range agents {
+		bundled["agent:"+name] = true
+	}
+	for _, name := range skills {
+		bundled["skill:"+name] = true
+	}
+
+	for _, profile := range profiles {
+		for _, agent := range profile.Agents {
+			if agent != "*" && !bundled["agent:"+agent+".md"] {
+				t.Errorf("profile %s: agent %q is not bundled", profile.Name, agent)
+			}
+		}
+		for _, skill := range profile.Skills {
+			if skill != "*" && !bundled["skill:"+skill] {
+				t.Errorf("profile %s: skill %q is not bundled", profile.Name, skill)
+			}
+		}
+	}
+}
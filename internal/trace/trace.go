@@ -0,0 +1,322 @@
+// Package trace records timing spans for a single agentctl run so slow
+// commands can be broken down into agent calls, git subprocesses, and
+// network requests after the fact.
+package trace
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ryantking/agentctl/internal/fsutil"
+)
+
+// EnvVar enables tracing for any command when set to a non-empty value,
+// which is useful for hooks where flags cannot be added.
+const EnvVar = "AGENTCTL_TRACE"
+
+// Span kinds used across agentctl.
+const (
+	KindCommand = "command"
+	KindAgent   = "agent"
+	KindGit     = "git"
+	KindMCP     = "mcp"
+	KindExec    = "exec"
+	KindHTTP    = "http"
+)
+
+// Span is a single timed operation within a run.
+type Span struct {
+	ID       int               `json:"id"`
+	ParentID int               `json:"parent_id,omitempty"`
+	Kind     string            `json:"kind"`
+	Name     string            `json:"name"`
+	Start    time.Time         `json:"start"`
+	Duration time.Duration     `json:"duration_ns"`
+	Error    string            `json:"error,omitempty"`
+	Attrs    map[string]string `json:"attrs,omitempty"`
+
+	ended bool
+}
+
+// Run is the trace of one agentctl invocation.
+type Run struct {
+	ID      string    `json:"id"`
+	Command string    `json:"command"`
+	Start   time.Time `json:"start"`
+	Spans   []*Span   `json:"spans"`
+}
+
+var (
+	mu      sync.Mutex
+	current *Run
+	stack   []*Span
+)
+
+// Enabled reports whether a run is being traced.
+func Enabled() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return current != nil
+}
+
+// Begin starts tracing a run and opens its root command span.
+// Returns the run ID.
+func Begin(command string) string {
+	id := newRunID()
+
+	mu.Lock()
+	current = &Run{ID: id, Command: command, Start: time.Now().UTC()}
+	stack = nil
+	mu.Unlock()
+
+	StartSpan(KindCommand, command)
+	return id
+}
+
+// StartSpan opens a span nested under the innermost open span.
+// It returns nil when tracing is disabled; End is safe to call on nil.
+func StartSpan(kind, name string) *Span {
+	mu.Lock()
+	defer mu.Unlock()
+	if current == nil {
+		return nil
+	}
+
+	span := &Span{
+		ID:    len(current.Spans) + 1,
+		Kind:  kind,
+		Name:  name,
+		Start: time.Now().UTC(),
+	}
+	if len(stack) > 0 {
+		span.ParentID = stack[len(stack)-1].ID
+	}
+	current.Spans = append(current.Spans, span)
+	stack = append(stack, span)
+	return span
+}
+
+// Child opens a span directly under s without making it the parent of later
+// spans. Use it for work fanned out across goroutines, where the implicit
+// nesting of StartSpan would attach siblings to each other.
+func (s *Span) Child(kind, name string) *Span {
+	if s == nil {
+		return nil
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if current == nil {
+		return nil
+	}
+
+	span := &Span{
+		ID:       len(current.Spans) + 1,
+		ParentID: s.ID,
+		Kind:     kind,
+		Name:     name,
+		Start:    time.Now().UTC(),
+	}
+	current.Spans = append(current.Spans, span)
+	return span
+}
+
+// SetAttr records a key/value attribute on the span.
+func (s *Span) SetAttr(key, value string) {
+	if s == nil {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if s.Attrs == nil {
+		s.Attrs = make(map[string]string)
+	}
+	s.Attrs[key] = value
+}
+
+// End closes the span, recording err if non-nil.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if s.ended {
+		return
+	}
+	s.ended = true
+	s.Duration = time.Since(s.Start)
+	if err != nil {
+		s.Error = err.Error()
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i] == s {
+			stack = append(stack[:i], stack[i+1:]...)
+			break
+		}
+	}
+}
+
+// Finish closes any open spans, writes the run to the trace directory, and
+// stops tracing. Returns the path written, or "" when tracing is disabled.
+func Finish(err error) (string, error) {
+	mu.Lock()
+	run := current
+	open := append([]*Span(nil), stack...)
+	mu.Unlock()
+	if run == nil {
+		return "", nil
+	}
+
+	// Close innermost spans first so durations nest correctly
+	for i := len(open) - 1; i >= 0; i-- {
+		if i == 0 {
+			open[i].End(err)
+		} else {
+			open[i].End(nil)
+		}
+	}
+
+	mu.Lock()
+	current = nil
+	stack = nil
+	mu.Unlock()
+
+	dir, dirErr := Dir()
+	if dirErr != nil {
+		return "", dirErr
+	}
+	if err := fsutil.MkdirAll(dir); err != nil {
+		return "", err
+	}
+	data, marshalErr := json.MarshalIndent(run, "", "  ")
+	if marshalErr != nil {
+		return "", marshalErr
+	}
+	path := filepath.Join(dir, run.ID+".json")
+	if err := fsutil.WriteFile(path, append(data, '\n'), fsutil.FileMode()); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Dir returns the directory traces are written to.
+func Dir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "agentctl", "traces"), nil
+}
+
+// Load reads a trace by run ID. A unique ID prefix is accepted, and "latest"
+// (or an empty ID) selects the most recent run.
+func Load(id string) (*Run, error) {
+	runs, err := List()
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, fmt.Errorf("no traces recorded (run a command with --trace)")
+	}
+	if id == "" || id == "latest" {
+		return runs[0], nil
+	}
+
+	var match *Run
+	for _, run := range runs {
+		if !strings.HasPrefix(run.ID, id) {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("trace ID %q is ambiguous", id)
+		}
+		match = run
+	}
+	if match == nil {
+		return nil, fmt.Errorf("trace not found: %s", id)
+	}
+	return match, nil
+}
+
+// List returns all recorded runs, newest first.
+func List() ([]*Run, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []*Run
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name())) //nolint:gosec // Path is inside the trace directory
+		if err != nil {
+			continue
+		}
+		var run Run
+		if err := json.Unmarshal(data, &run); err != nil {
+			continue
+		}
+		runs = append(runs, &run)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Start.After(runs[j].Start) })
+	return runs, nil
+}
+
+// Node is a span positioned in the run's span tree.
+type Node struct {
+	*Span
+	Depth int
+}
+
+// Tree returns spans in depth-first order with their nesting depth.
+func (r *Run) Tree() []Node {
+	children := make(map[int][]*Span)
+	for _, span := range r.Spans {
+		children[span.ParentID] = append(children[span.ParentID], span)
+	}
+
+	var nodes []Node
+	var walk func(parentID, depth int)
+	walk = func(parentID, depth int) {
+		for _, span := range children[parentID] {
+			nodes = append(nodes, Node{Span: span, Depth: depth})
+			walk(span.ID, depth+1)
+		}
+	}
+	walk(0, 0)
+	return nodes
+}
+
+// Duration returns the length of the run's root span.
+func (r *Run) Duration() time.Duration {
+	for _, span := range r.Spans {
+		if span.ParentID == 0 {
+			return span.Duration
+		}
+	}
+	return 0
+}
+
+// newRunID returns a sortable, unique run identifier.
+func newRunID() string {
+	suffix := make([]byte, 3)
+	_, _ = rand.Read(suffix)
+	return time.Now().UTC().Format("20060102T150405") + "-" + hex.EncodeToString(suffix)
+}
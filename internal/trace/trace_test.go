@@ -0,0 +1,66 @@
+package trace
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSpanNesting(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	id := Begin("agentctl test")
+	outer := StartSpan(KindAgent, "outer")
+	inner := StartSpan(KindGit, "inner")
+	inner.End(nil)
+	sibling := StartSpan(KindGit, "sibling")
+	sibling.End(errors.New("boom"))
+	child := outer.Child(KindMCP, "fanout")
+	child.End(nil)
+	outer.End(nil)
+
+	if _, err := Finish(nil); err != nil {
+		t.Fatalf("Finish() error = %v", err)
+	}
+	if Enabled() {
+		t.Fatal("Enabled() = true after Finish")
+	}
+
+	run, err := Load(id[:10])
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := []struct {
+		name  string
+		depth int
+	}{
+		{"agentctl test", 0},
+		{"outer", 1},
+		{"inner", 2},
+		{"sibling", 2},
+		{"fanout", 2},
+	}
+	nodes := run.Tree()
+	if len(nodes) != len(want) {
+		t.Fatalf("Tree() returned %d nodes, want %d", len(nodes), len(want))
+	}
+	for i, w := range want {
+		if nodes[i].Name != w.name || nodes[i].Depth != w.depth {
+			t.Errorf("node %d = %s@%d, want %s@%d", i, nodes[i].Name, nodes[i].Depth, w.name, w.depth)
+		}
+	}
+	if nodes[3].Error != "boom" {
+		t.Errorf("sibling error = %q, want %q", nodes[3].Error, "boom")
+	}
+}
+
+func TestDisabledSpansAreNoops(t *testing.T) {
+	span := StartSpan(KindGit, "ignored")
+	if span != nil {
+		t.Fatal("StartSpan() returned a span while tracing is disabled")
+	}
+	span.SetAttr("k", "v")
+	span.Child(KindGit, "child").End(nil)
+	span.End(nil)
+}
@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ErrNotInteractive is returned by Confirm when stdin is not a terminal.
+var ErrNotInteractive = fmt.Errorf("confirmation required but stdin is not a terminal")
+
+// Confirm asks a yes/no question on stderr and reads the answer from stdin.
+// Anything other than "y" or "yes" is treated as no.
+func Confirm(question string) (bool, error) {
+	if !isTerminal(os.Stdin) {
+		return false, ErrNotInteractive
+	}
+
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, nil
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
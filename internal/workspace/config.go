@@ -0,0 +1,139 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/ryantking/agentctl/internal/fsutil"
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// configFileName is stored in each worktree's private git directory
+// (.git for the main checkout, .git/worktrees/<name> otherwise), so settings
+// are per-workspace, never committed, and removed along with the worktree.
+const configFileName = "agentctl-workspace.json"
+
+// Config keys accepted by Config.Set.
+const (
+	ConfigHookAutoCommit = "hooks.autocommit"
+	ConfigHookNotify     = "hooks.notify"
+)
+
+// HookPolicy controls which agentctl hooks act in a workspace.
+// Nil values mean "not set" and fall back to the default (enabled).
+type HookPolicy struct {
+	AutoCommit *bool `json:"autocommit,omitempty"`
+	Notify     *bool `json:"notify,omitempty"`
+}
+
+// Config holds per-workspace agentctl settings.
+type Config struct {
+	Hooks HookPolicy `json:"hooks"`
+}
+
+// AutoCommitEnabled reports whether the post-edit/post-write hooks may commit.
+func (c *Config) AutoCommitEnabled() bool {
+	return c.Hooks.AutoCommit == nil || *c.Hooks.AutoCommit
+}
+
+// NotifyEnabled reports whether notification hooks may send notifications.
+func (c *Config) NotifyEnabled() bool {
+	return c.Hooks.Notify == nil || *c.Hooks.Notify
+}
+
+// Values returns the effective value of every config key.
+func (c *Config) Values() map[string]bool {
+	return map[string]bool{
+		ConfigHookAutoCommit: c.AutoCommitEnabled(),
+		ConfigHookNotify:     c.NotifyEnabled(),
+	}
+}
+
+// ConfigKeys returns the supported config keys in sorted order.
+func ConfigKeys() []string {
+	keys := []string{ConfigHookAutoCommit, ConfigHookNotify}
+	sort.Strings(keys)
+	return keys
+}
+
+// Set parses and stores a value for key. Short keys ("autocommit") are accepted.
+func (c *Config) Set(key, value string) error {
+	field, err := c.field(key)
+	if err != nil {
+		return err
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid value %q for %s: expected true or false", value, key)
+	}
+	*field = &enabled
+	return nil
+}
+
+// Unset resets key to its default.
+func (c *Config) Unset(key string) error {
+	field, err := c.field(key)
+	if err != nil {
+		return err
+	}
+	*field = nil
+	return nil
+}
+
+func (c *Config) field(key string) (**bool, error) {
+	switch key {
+	case ConfigHookAutoCommit, "autocommit":
+		return &c.Hooks.AutoCommit, nil
+	case ConfigHookNotify, "notify":
+		return &c.Hooks.Notify, nil
+	}
+	return nil, fmt.Errorf("unknown config key %q (valid keys: %s, %s)", key, ConfigHookAutoCommit, ConfigHookNotify)
+}
+
+// configPath returns the config file location for the worktree containing path.
+func configPath(path string) (string, error) {
+	gitDir, err := git.RunGit(path, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", git.ErrNotInGitRepo
+	}
+	return filepath.Join(gitDir, configFileName), nil
+}
+
+// LoadConfig loads the config for the worktree containing path.
+// A missing file yields the default config.
+func LoadConfig(path string) (*Config, error) {
+	cfgPath, err := configPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	data, err := os.ReadFile(cfgPath) //nolint:gosec // Path is inside the git directory
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", cfgPath, err)
+	}
+	return cfg, nil
+}
+
+// SaveConfig writes the config for the worktree containing path.
+func SaveConfig(path string, cfg *Config) error {
+	cfgPath, err := configPath(path)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFile(cfgPath, append(data, '\n'), fsutil.FileMode())
+}
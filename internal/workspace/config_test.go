@@ -0,0 +1,153 @@
+package workspace
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ryantking/agentctl/internal/git"
+)
+
+// initRepo creates a repository with one commit and a linked worktree,
+// returning both paths.
+func initRepo(t *testing.T) (string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	git.InvalidateCache()
+
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "repo")
+	worktree := filepath.Join(dir, "feature")
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main", repo},
+		{"-C", repo, "commit", "-q", "--allow-empty", "-m", "init"},
+		{"-C", repo, "worktree", "add", "-q", "-b", "feature", worktree},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	return repo, worktree
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+	repo, _ := initRepo(t)
+
+	cfg, err := LoadConfig(repo)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if !cfg.AutoCommitEnabled() || !cfg.NotifyEnabled() {
+		t.Errorf("default config = %+v, want every hook enabled", cfg.Hooks)
+	}
+	want := map[string]bool{ConfigHookAutoCommit: true, ConfigHookNotify: true}
+	if got := cfg.Values(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+
+	if _, err := LoadConfig(t.TempDir()); err != git.ErrNotInGitRepo {
+		t.Errorf("LoadConfig() outside a repository error = %v, want ErrNotInGitRepo", err)
+	}
+}
+
+func TestConfigSaveLoad(t *testing.T) {
+	repo, worktree := initRepo(t)
+
+	cfg := &Config{}
+	if err := cfg.Set("autocommit", "false"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveConfig(worktree, cfg); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+
+	loaded, err := LoadConfig(filepath.Join(worktree, "."))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if loaded.AutoCommitEnabled() || !loaded.NotifyEnabled() {
+		t.Errorf("loaded config = %+v, want autocommit off and notify on", loaded.Hooks)
+	}
+
+	// Settings belong to one worktree and stay out of the working tree
+	main, err := LoadConfig(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !main.AutoCommitEnabled() {
+		t.Errorf("config saved in the feature worktree applied to the main checkout")
+	}
+	if _, err := os.Stat(filepath.Join(worktree, configFileName)); !os.IsNotExist(err) {
+		t.Errorf("config file written into the working tree")
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, ".git", configFileName), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(repo); err == nil || !strings.Contains(err.Error(), "failed to parse") {
+		t.Errorf("LoadConfig() of a corrupt file error = %v", err)
+	}
+}
+
+func TestConfigSet(t *testing.T) {
+	tests := []struct {
+		name           string
+		key, value     string
+		wantErr        string
+		wantAutoCommit bool
+		wantNotify     bool
+	}{
+		{"full key", ConfigHookAutoCommit, "false", "", false, true},
+		{"short key", "notify", "0", "", true, false},
+		{"enable", "autocommit", "true", "", true, true},
+		{"invalid value", ConfigHookNotify, "sometimes", `invalid value "sometimes"`, true, true},
+		{"unknown key", "hooks.review", "true", `unknown config key "hooks.review"`, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{}
+			err := cfg.Set(tt.key, tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Set() error = %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			if cfg.AutoCommitEnabled() != tt.wantAutoCommit || cfg.NotifyEnabled() != tt.wantNotify {
+				t.Errorf("after Set(%q, %q) = %+v", tt.key, tt.value, cfg.Values())
+			}
+		})
+	}
+}
+
+func TestConfigUnset(t *testing.T) {
+	cfg := &Config{}
+	if err := cfg.Set(ConfigHookNotify, "false"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.Unset("notify"); err != nil {
+		t.Fatalf("Unset() error = %v", err)
+	}
+	if cfg.Hooks.Notify != nil || !cfg.NotifyEnabled() {
+		t.Errorf("Unset() left notify = %v", cfg.Hooks.Notify)
+	}
+	if err := cfg.Unset("bogus"); err == nil {
+		t.Errorf("Unset() accepted an unknown key")
+	}
+
+	if got, want := ConfigKeys(), []string{ConfigHookAutoCommit, ConfigHookNotify}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ConfigKeys() = %v, want %v", got, want)
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strconv"
 
+	"github.com/ryantking/agentctl/internal/fsutil"
 	"github.com/ryantking/agentctl/internal/git"
 )
 
@@ -59,7 +59,8 @@ func (m *WorkspaceManager) GetWorkspace(branch string) (*Workspace, error) {
 }
 
 // CreateWorkspace creates a new workspace with worktree.
-func (m *WorkspaceManager) CreateWorkspace(branch string, baseBranch string) (*Workspace, error) {
+// If sparsePaths is non-empty, the worktree uses sparse-checkout limited to those paths.
+func (m *WorkspaceManager) CreateWorkspace(branch string, baseBranch string, sparsePaths []string) (*Workspace, error) {
 	workspacePath, err := GetWorkspacePath(branch, m.repoRoot)
 	if err != nil {
 		return nil, err
@@ -77,7 +78,7 @@ func (m *WorkspaceManager) CreateWorkspace(branch string, baseBranch string) (*W
 	}
 
 	// Create parent directory
-	if err := os.MkdirAll(filepath.Dir(workspacePath), 0755); err != nil { //nolint:gosec // Workspace directories need to be readable
+	if err := fsutil.MkdirAll(filepath.Dir(workspacePath)); err != nil {
 		return nil, fmt.Errorf("failed to create workspace directory: %w", err)
 	}
 
@@ -89,7 +90,7 @@ func (m *WorkspaceManager) CreateWorkspace(branch string, baseBranch string) (*W
 
 	if branchExists {
 		// Branch exists, just create worktree
-		if err := git.AddWorktree(m.repoRoot, workspacePath, branch, false, ""); err != nil {
+		if err := m.addWorktree(workspacePath, branch, false, "", sparsePaths); err != nil {
 			return nil, fmt.Errorf("failed to create worktree: %w", err)
 		}
 	} else {
@@ -100,7 +101,7 @@ func (m *WorkspaceManager) CreateWorkspace(branch string, baseBranch string) (*W
 				baseBranch = "HEAD"
 			}
 		}
-		if err := git.AddWorktree(m.repoRoot, workspacePath, branch, true, baseBranch); err != nil {
+		if err := m.addWorktree(workspacePath, branch, true, baseBranch, sparsePaths); err != nil {
 			return nil, fmt.Errorf("failed to create worktree: %w", err)
 		}
 	}
@@ -116,6 +117,14 @@ func (m *WorkspaceManager) CreateWorkspace(branch string, baseBranch string) (*W
 	return workspace, nil
 }
 
+// addWorktree creates a full or sparse worktree depending on sparsePaths.
+func (m *WorkspaceManager) addWorktree(path, branch string, createBranch bool, baseBranch string, sparsePaths []string) error {
+	if len(sparsePaths) > 0 {
+		return git.AddSparseWorktree(m.repoRoot, path, branch, createBranch, baseBranch, sparsePaths)
+	}
+	return git.AddWorktree(m.repoRoot, path, branch, createBranch, baseBranch)
+}
+
 // DeleteWorkspace removes a workspace.
 func (m *WorkspaceManager) DeleteWorkspace(branch string, force bool) error {
 	workspace, err := m.GetWorkspace(branch)
@@ -154,32 +163,78 @@ func (m *WorkspaceManager) DeleteWorkspace(branch string, force bool) error {
 	return nil
 }
 
-// CleanWorkspaces removes clean/merged workspaces.
-func (m *WorkspaceManager) CleanWorkspaces(checkMerged bool) ([]string, error) {
-	var removed []string
+// CleanOptions selects which workspaces CleanWorkspaces removes.
+type CleanOptions struct {
+	// MergedInto, when set, also requires every commit on a workspace's branch
+	// to be in this branch, so clean but unmerged work is kept.
+	MergedInto string
+	// DryRun reports what would be removed without removing anything.
+	DryRun bool
+}
+
+// CleanResult reports the outcome of CleanWorkspaces.
+type CleanResult struct {
+	Removed []string `json:"removed"`
+	// Skipped maps each kept workspace's branch to the reason it was kept.
+	Skipped map[string]string `json:"skipped"`
+}
+
+// CleanWorkspaces removes managed workspaces that have no uncommitted
+// changes and, with MergedInto, no unmerged commits. Locked workspaces are
+// always kept.
+func (m *WorkspaceManager) CleanWorkspaces(opts CleanOptions) (*CleanResult, error) {
+	result := &CleanResult{Removed: []string{}, Skipped: map[string]string{}}
 	workspaces, err := m.ListWorkspaces(true)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, workspace := range workspaces {
-		if workspace.IsMain {
+		if workspace.IsMain || workspace.Branch == "" {
 			continue
 		}
 
-		isClean, _ := workspace.IsClean()
-		if !checkMerged || isClean {
-			if workspace.Branch != "" {
-				if err := m.DeleteWorkspace(workspace.Branch, !checkMerged); err != nil {
-					// Skip workspaces that can't be deleted
-					continue
-				}
-				removed = append(removed, workspace.Branch)
+		if reason := m.cleanBlocker(&workspace, opts.MergedInto); reason != "" {
+			result.Skipped[workspace.Branch] = reason
+			continue
+		}
+
+		if !opts.DryRun {
+			if err := m.DeleteWorkspace(workspace.Branch, false); err != nil {
+				result.Skipped[workspace.Branch] = err.Error()
+				continue
 			}
 		}
+		result.Removed = append(result.Removed, workspace.Branch)
+	}
+
+	return result, nil
+}
+
+// cleanBlocker returns why workspace must not be cleaned, or "" if it may be.
+func (m *WorkspaceManager) cleanBlocker(workspace *Workspace, mergedInto string) string {
+	if workspace.Locked {
+		return "locked"
+	}
+	if isClean, status := workspace.IsClean(); !isClean {
+		return "uncommitted changes (" + status + ")"
+	}
+	if mergedInto != "" {
+		unmerged, err := git.UnmergedCommits(m.repoRoot, workspace.Branch, mergedInto)
+		if err != nil {
+			return err.Error()
+		}
+		if unmerged > 0 {
+			return fmt.Sprintf("%d commit(s) not merged into %s", unmerged, mergedInto)
+		}
 	}
+	return ""
+}
 
-	return removed, nil
+// Fetch updates the repository's remote-tracking branches from origin so
+// status comparisons are current.
+func (m *WorkspaceManager) Fetch() error {
+	return git.Fetch(m.repoRoot)
 }
 
 // GetWorkspaceStatus gets detailed status information for a workspace.
@@ -194,61 +249,64 @@ func (m *WorkspaceManager) GetWorkspaceStatus(workspace *Workspace) (map[string]
 		"status":   status,
 	}
 
-	// Get ahead/behind information
+	// Get ahead/behind information relative to the branch's upstream,
+	// falling back to origin/<branch> when no upstream is configured
 	if workspace.Branch != "" {
-		// Get local branch commit
-		localCommit, err := git.RunGit(workspace.Path, "rev-parse", "HEAD")
-		if err == nil {
-			// Get remote branch commit
-			remoteCommit, err := git.RunGit(workspace.Path, "rev-parse", fmt.Sprintf("origin/%s", workspace.Branch))
-			if err == nil {
-				// Calculate ahead/behind
-				ahead, behind, err := calculateAheadBehind(workspace.Path, localCommit, remoteCommit)
-				if err == nil {
-					result["ahead_behind"] = map[string]int{
-						"ahead":  ahead,
-						"behind": behind,
-					}
+		upstream := "origin/" + workspace.Branch
+		if heads, err := git.ListBranchHeads(m.repoRoot); err == nil {
+			if head, ok := heads[workspace.Branch]; ok && head.Upstream != "" {
+				upstream = head.Upstream
+				if head.UpstreamGone {
+					result["upstream"] = upstream
+					result["upstream_gone"] = true
+					return result, nil
 				}
 			}
 		}
+
+		ahead, behind, err := git.AheadBehind(workspace.Path, "HEAD", upstream)
+		if err == nil {
+			result["upstream"] = upstream
+			result["ahead_behind"] = map[string]int{
+				"ahead":  ahead,
+				"behind": behind,
+			}
+		}
 	}
 
 	return result, nil
 }
 
-// calculateAheadBehind calculates how many commits ahead and behind local is compared to remote.
-func calculateAheadBehind(repoPath, localCommit, remoteCommit string) (int, int, error) {
-	// Count commits in local but not in remote (ahead)
-	aheadStr, err := git.RunGit(repoPath, "rev-list", "--count", fmt.Sprintf("%s..%s", remoteCommit, localCommit))
-	if err != nil {
-		return 0, 0, err
-	}
-	ahead, err := strconv.Atoi(aheadStr)
+// GetWorkspaceDiff gets the git diff of the workspace's commits since it
+// diverged from the target branch.
+func (m *WorkspaceManager) GetWorkspaceDiff(workspace *Workspace, targetBranch string) (string, error) {
+	// Three-dot diff from the merge base, so later commits on the target
+	// branch don't show up as reverted changes
+	diff, err := git.RunGit(workspace.Path, "diff", "--no-color", fmt.Sprintf("%s...HEAD", targetBranch))
 	if err != nil {
-		return 0, 0, err
+		return "", fmt.Errorf("failed to get diff: %w", err)
 	}
 
-	// Count commits in remote but not in local (behind)
-	behindStr, err := git.RunGit(repoPath, "rev-list", "--count", fmt.Sprintf("%s..%s", localCommit, remoteCommit))
-	if err != nil {
-		return 0, 0, err
-	}
-	behind, err := strconv.Atoi(behindStr)
+	return diff, nil
+}
+
+// GetWorkspaceLog gets the messages of the workspace's commits that are not
+// on the target branch, oldest first.
+func (m *WorkspaceManager) GetWorkspaceLog(workspace *Workspace, targetBranch string) (string, error) {
+	log, err := git.RunGit(workspace.Path, "log", "--no-merges", "--reverse", "--format=commit %h%n%B", fmt.Sprintf("%s..HEAD", targetBranch))
 	if err != nil {
-		return 0, 0, err
+		return "", fmt.Errorf("failed to get commit log: %w", err)
 	}
 
-	return ahead, behind, nil
+	return log, nil
 }
 
-// GetWorkspaceDiff gets git diff from workspace to target branch.
-func (m *WorkspaceManager) GetWorkspaceDiff(workspace *Workspace, targetBranch string) (string, error) {
-	// Get diff between target branch and HEAD
-	diff, err := git.RunGit(workspace.Path, "diff", fmt.Sprintf("%s..HEAD", targetBranch))
-	if err != nil {
-		return "", fmt.Errorf("failed to get diff: %w", err)
+// DefaultBaseBranch returns the branch checked out in the main worktree,
+// which workspaces are usually created from and merged back into.
+func (m *WorkspaceManager) DefaultBaseBranch() string {
+	workspaces, err := DiscoverWorkspaces(m.repoRoot)
+	if err == nil && len(workspaces) > 0 && workspaces[0].Branch != "" {
+		return workspaces[0].Branch
 	}
-
-	return diff, nil
+	return "main"
 }
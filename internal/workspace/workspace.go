@@ -15,6 +15,9 @@ type Workspace struct {
 	Commit   string
 	IsMain   bool
 	RepoRoot string
+	// Locked and Prunable mirror git worktree lock state and staleness.
+	Locked   bool
+	Prunable bool
 }
 
 // IsManaged checks if this workspace is managed by agentctl.
@@ -26,6 +29,9 @@ func (w *Workspace) IsManaged() bool {
 // IsClean checks if workspace has uncommitted changes.
 // Returns (isClean, statusMessage).
 func (w *Workspace) IsClean() (bool, string) {
+	if w.Prunable {
+		return false, "missing (prunable)"
+	}
 	return git.IsWorktreeClean(w.Path)
 }
 
@@ -40,6 +46,8 @@ func (w *Workspace) ToMap() map[string]interface{} {
 		"is_managed": w.IsManaged(),
 		"is_clean":   isClean,
 		"status":     status,
+		"locked":     w.Locked,
+		"prunable":   w.Prunable,
 	}
 }
 
@@ -51,15 +59,21 @@ func DiscoverWorkspaces(repoRoot string) ([]Workspace, error) {
 		return nil, err
 	}
 
-	workspaces := make([]Workspace, len(worktrees))
+	workspaces := make([]Workspace, 0, len(worktrees))
 	for i, wt := range worktrees {
-		workspaces[i] = Workspace{
+		// A bare repository has no checkout to work in
+		if wt.Bare {
+			continue
+		}
+		workspaces = append(workspaces, Workspace{
 			Path:     wt.Path,
 			Branch:   wt.Branch,
 			Commit:   wt.Commit,
 			IsMain:   i == 0,
 			RepoRoot: repoRoot,
-		}
+			Locked:   wt.Locked,
+			Prunable: wt.Prunable,
+		})
 	}
 	return workspaces, nil
 }
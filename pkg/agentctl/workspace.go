@@ -0,0 +1,190 @@
+// Package agentctl exposes agentctl's workspace management as a stable Go API
+// so CI tools and bots can create, inspect, and remove agent workspaces
+// without shelling out to the CLI.
+//
+// Workspaces are git worktrees kept under ~/.claude/workspaces/<repo>/, the
+// same ones the agentctl workspace commands manage.
+package agentctl
+
+import (
+	"fmt"
+
+	"github.com/ryantking/agentctl/internal/git"
+	"github.com/ryantking/agentctl/internal/workspace"
+)
+
+// Errors returned by Workspaces methods, for use with errors.Is.
+var (
+	ErrWorkspaceExists   = workspace.ErrWorkspaceExists
+	ErrWorkspaceNotFound = workspace.ErrWorkspaceNotFound
+	ErrBranchInUse       = workspace.ErrBranchInUse
+	ErrNotInGitRepo      = workspace.ErrNotInGitRepo
+)
+
+// Workspace is a git worktree of a repository.
+type Workspace struct {
+	// Path is the worktree's directory.
+	Path string `json:"path"`
+	// Branch is the checked-out branch, empty for a detached HEAD.
+	Branch string `json:"branch"`
+	// Commit is the abbreviated HEAD commit.
+	Commit string `json:"commit"`
+	// Main is true for the repository's primary checkout.
+	Main bool `json:"is_main"`
+	// Managed is true for workspaces created by agentctl.
+	Managed bool `json:"is_managed"`
+}
+
+// WorkspaceStatus describes a workspace's uncommitted changes and how it
+// compares to its branch on origin.
+type WorkspaceStatus struct {
+	Workspace
+	// Clean is true when there are no staged, modified, or untracked files.
+	Clean bool `json:"is_clean"`
+	// Summary describes the changes, e.g. "2 modified, 1 untracked".
+	Summary string `json:"status"`
+	// Upstream is the remote branch compared against: the configured
+	// upstream, or origin/<branch>. Empty when neither exists.
+	Upstream string `json:"upstream,omitempty"`
+	// UpstreamGone is set when the upstream was deleted on the remote.
+	UpstreamGone bool `json:"upstream_gone,omitempty"`
+	// Ahead and Behind count commits relative to Upstream.
+	Ahead  int `json:"ahead"`
+	Behind int `json:"behind"`
+}
+
+// CreateOptions configures Workspaces.Create.
+type CreateOptions struct {
+	// Base is the branch a new branch starts from. Defaults to the
+	// repository's current branch. Ignored when the branch already exists.
+	Base string
+	// Sparse limits the checkout to these paths using sparse-checkout.
+	Sparse []string
+}
+
+// Workspaces manages the workspaces of one repository.
+type Workspaces struct {
+	repoRoot string
+	manager  *workspace.WorkspaceManager
+}
+
+// OpenWorkspaces returns the workspace manager for the repository containing path.
+func OpenWorkspaces(path string) (*Workspaces, error) {
+	repoRoot, err := git.GetRepoRootFromPath(path)
+	if err != nil {
+		return nil, ErrNotInGitRepo
+	}
+	manager, err := workspace.NewManagerAt(repoRoot)
+	if err != nil {
+		return nil, err
+	}
+	return &Workspaces{repoRoot: repoRoot, manager: manager}, nil
+}
+
+// RepoRoot returns the repository's top-level directory.
+func (w *Workspaces) RepoRoot() string {
+	return w.repoRoot
+}
+
+// List returns the repository's workspaces, the main checkout first.
+// With managedOnly, only workspaces created by agentctl are returned.
+func (w *Workspaces) List(managedOnly bool) ([]Workspace, error) {
+	workspaces, err := w.manager.ListWorkspaces(managedOnly)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Workspace, len(workspaces))
+	for i := range workspaces {
+		result[i] = fromInternal(&workspaces[i])
+	}
+	return result, nil
+}
+
+// Get returns the workspace with branch checked out.
+func (w *Workspaces) Get(branch string) (Workspace, error) {
+	ws, err := w.manager.GetWorkspace(branch)
+	if err != nil {
+		return Workspace{}, err
+	}
+	return fromInternal(ws), nil
+}
+
+// Create creates a workspace for branch, creating the branch if needed.
+func (w *Workspaces) Create(branch string, opts CreateOptions) (Workspace, error) {
+	ws, err := w.manager.CreateWorkspace(branch, opts.Base, opts.Sparse)
+	if err != nil {
+		return Workspace{}, err
+	}
+	return fromInternal(ws), nil
+}
+
+// Delete removes the workspace for branch. Without force, workspaces with
+// uncommitted changes are refused.
+func (w *Workspaces) Delete(branch string, force bool) error {
+	return w.manager.DeleteWorkspace(branch, force)
+}
+
+// Clean removes every managed workspace without uncommitted changes. When
+// mergedInto is non-empty, workspaces with commits not yet in that branch are
+// kept too. It returns the branches removed and, for each workspace kept, the
+// reason.
+func (w *Workspaces) Clean(mergedInto string) (removed []string, skipped map[string]string, err error) {
+	result, err := w.manager.CleanWorkspaces(workspace.CleanOptions{MergedInto: mergedInto})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result.Removed, result.Skipped, nil
+}
+
+// Status returns the change summary and upstream position of the workspace for branch.
+func (w *Workspaces) Status(branch string) (WorkspaceStatus, error) {
+	ws, err := w.manager.GetWorkspace(branch)
+	if err != nil {
+		return WorkspaceStatus{}, err
+	}
+
+	info, err := w.manager.GetWorkspaceStatus(ws)
+	if err != nil {
+		return WorkspaceStatus{}, err
+	}
+
+	status := WorkspaceStatus{Workspace: fromInternal(ws)}
+	status.Clean, _ = info["is_clean"].(bool)
+	status.Summary, _ = info["status"].(string)
+	status.Upstream, _ = info["upstream"].(string)
+	status.UpstreamGone, _ = info["upstream_gone"].(bool)
+	if aheadBehind, ok := info["ahead_behind"].(map[string]int); ok {
+		status.Ahead, status.Behind = aheadBehind["ahead"], aheadBehind["behind"]
+	}
+	return status, nil
+}
+
+// Fetch updates remote-tracking branches from origin, pruning deleted ones,
+// so Status compares against current remote state.
+func (w *Workspaces) Fetch() error {
+	return w.manager.Fetch()
+}
+
+// Diff returns the changes committed in the workspace for branch since it
+// diverged from target.
+func (w *Workspaces) Diff(branch, target string) (string, error) {
+	ws, err := w.manager.GetWorkspace(branch)
+	if err != nil {
+		return "", err
+	}
+	diff, err := w.manager.GetWorkspaceDiff(ws, target)
+	if err != nil {
+		return "", fmt.Errorf("workspace %s: %w", branch, err)
+	}
+	return diff, nil
+}
+
+func fromInternal(ws *workspace.Workspace) Workspace {
+	return Workspace{
+		Path:    ws.Path,
+		Branch:  ws.Branch,
+		Commit:  ws.Commit,
+		Main:    ws.IsMain,
+		Managed: ws.IsManaged(),
+	}
+}
@@ -0,0 +1,76 @@
+package agentctl
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorkspaces(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("HOME", t.TempDir())
+
+	repo := filepath.Join(t.TempDir(), "repo")
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main", repo},
+		{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "--allow-empty", "-m", "init"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	ws, err := OpenWorkspaces(repo)
+	if err != nil {
+		t.Fatalf("OpenWorkspaces() error = %v", err)
+	}
+
+	created, err := ws.Create("feature/api", CreateOptions{})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if !created.Managed || created.Main || created.Branch != "feature/api" {
+		t.Errorf("Create() = %+v", created)
+	}
+
+	if err := os.WriteFile(filepath.Join(created.Path, "new.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	status, err := ws.Status("feature/api")
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+	if status.Clean {
+		t.Errorf("Status() = %+v, want uncommitted changes", status)
+	}
+
+	list, err := ws.List(false)
+	if err != nil || len(list) != 2 || !list[0].Main {
+		t.Fatalf("List() = %+v, %v; want main checkout then workspace", list, err)
+	}
+
+	if _, err := ws.Create("merged", CreateOptions{Base: "main"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	removed, skipped, err := ws.Clean("main")
+	if err != nil {
+		t.Fatalf("Clean() error = %v", err)
+	}
+	if len(removed) != 1 || removed[0] != "merged" || skipped["feature/api"] == "" {
+		t.Errorf("Clean() removed %v, skipped %v; want merged removed and feature/api kept", removed, skipped)
+	}
+
+	if err := ws.Delete("feature/api", false); err == nil {
+		t.Error("Delete() removed a workspace with uncommitted changes")
+	}
+	if err := ws.Delete("feature/api", true); err != nil {
+		t.Fatalf("Delete(force) error = %v", err)
+	}
+	if _, err := ws.Get("feature/api"); !errors.Is(err, ErrWorkspaceNotFound) {
+		t.Errorf("Get() after Delete error = %v, want ErrWorkspaceNotFound", err)
+	}
+}